@@ -0,0 +1,107 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Error es un error de la API con su código y los argumentos del mensaje.
+type Error struct {
+	Code Code
+	Args []any
+	// Datos adicionales que se devuelven en el campo meta
+	Meta map[string]any
+}
+
+// New crea un error con el código y los argumentos de su mensaje.
+func New(code Code, args ...any) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// With agrega un dato al campo meta de la respuesta.
+func (e *Error) With(key string, value any) *Error {
+	if e.Meta == nil {
+		e.Meta = map[string]any{}
+	}
+	e.Meta[key] = value
+	return e
+}
+
+func (e *Error) Error() string {
+	return Message(e.Code, i18n.Default, e.Args...)
+}
+
+// Status devuelve el estado HTTP del código.
+func (e *Error) Status() int {
+	if entry, ok := catalog[e.Code]; ok {
+		return entry.status
+	}
+	return http.StatusInternalServerError
+}
+
+// Message arma el mensaje de un código en el idioma indicado, o en el idioma
+// por defecto si no hay traducción.
+func Message(code Code, locale string, args ...any) string {
+	entry, ok := catalog[code]
+	if !ok {
+		entry = catalog[InternalError]
+	}
+	message, ok := entry.messages[locale]
+	if !ok {
+		message = entry.messages[i18n.Default]
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Body es el contenido del campo error de las respuestas fallidas.
+type Body struct {
+	Code    Code           `json:"code"`
+	Message string         `json:"message"`
+	Details []FieldError   `json:"details,omitempty"`
+	Meta    map[string]any `json:"meta,omitempty"`
+}
+
+// Response es el sobre de todas las respuestas de error:
+//
+//	{"error": {"code": "...", "message": "...", "details": [...], "meta": {...}}}
+type Response struct {
+	Error Body `json:"error"`
+}
+
+// Render responde el error en el idioma del pedido y corta la cadena de
+// handlers. Los errores de validación se responden con el detalle de cada
+// campo; cualquier error que no sea *Error se responde como internal_error
+// sin exponer su texto.
+func Render(c *gin.Context, err error) {
+	locale := utils.GetLocaleFromContext(c)
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		apiErr := New(ValidationFailed)
+		c.AbortWithStatusJSON(apiErr.Status(), Response{Error: Body{
+			Code:    apiErr.Code,
+			Message: Message(apiErr.Code, locale),
+			Details: fieldErrors(validationErrors, locale),
+		}})
+		return
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = New(InternalError)
+	}
+	c.AbortWithStatusJSON(apiErr.Status(), Response{Error: Body{
+		Code:    apiErr.Code,
+		Message: Message(apiErr.Code, locale, apiErr.Args...),
+		Meta:    apiErr.Meta,
+	}})
+}
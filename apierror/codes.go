@@ -0,0 +1,228 @@
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+)
+
+// Code identifica un error de la API. Los códigos son estables: el frontend
+// puede depender de ellos aunque cambien los mensajes.
+type Code string
+
+// Errores generales
+const (
+	InternalError    Code = "internal_error"
+	RouteNotFound    Code = "route_not_found"
+	InvalidBody      Code = "invalid_body"
+	ValidationFailed Code = "validation_failed"
+)
+
+// Parámetros de consulta
+const (
+	InvalidNumber     Code = "invalid_number"
+	InvalidBoolean    Code = "invalid_boolean"
+	InvalidRange      Code = "invalid_range"
+	ParamOutOfRange   Code = "param_out_of_range"
+	InvalidPage       Code = "invalid_page"
+	SearchTooLong     Code = "search_too_long"
+	InvalidGenreMatch Code = "invalid_genre_match"
+)
+
+// Autenticación y usuarios
+const (
+	TokenMissing          Code = "token_missing"
+	TokenInvalid          Code = "token_invalid"
+	RoleMissing           Code = "role_missing"
+	RoleRequired          Code = "role_required"
+	InvalidCredentials    Code = "invalid_credentials"
+	RefreshTokenMissing   Code = "refresh_token_missing"
+	RefreshTokenInvalid   Code = "refresh_token_invalid"
+	UserNotFound          Code = "user_not_found"
+	UserExists            Code = "user_exists"
+	UserCreateFailed      Code = "user_create_failed"
+	PasswordHashFailed    Code = "password_hash_failed"
+	TokenGenerationFailed Code = "token_generation_failed"
+	TokenUpdateFailed     Code = "token_update_failed"
+	LogoutFailed          Code = "logout_failed"
+)
+
+// Películas
+const (
+	MovieIDRequired     Code = "movie_id_required"
+	MovieNotFound       Code = "movie_not_found"
+	MovieExists         Code = "movie_exists"
+	MoviesFetchFailed   Code = "movies_fetch_failed"
+	MovieCreateFailed   Code = "movie_create_failed"
+	MovieUpdateFailed   Code = "movie_update_failed"
+	MovieSearchFailed   Code = "movie_search_failed"
+	SimilarMoviesFailed Code = "similar_movies_failed"
+	InvalidSort         Code = "invalid_sort"
+	InvalidWindow       Code = "invalid_window"
+
+	RecommendationsFailed Code = "recommendations_failed"
+)
+
+// Calificaciones
+const (
+	RatingNotFound Code = "rating_not_found"
+	RatingFailed   Code = "rating_failed"
+)
+
+// Reseñas
+const (
+	InvalidReviewID     Code = "invalid_review_id"
+	InvalidReviewStatus Code = "invalid_review_status"
+	ReviewNotFound      Code = "review_not_found"
+	ReviewExists        Code = "review_exists"
+	ReviewForbidden     Code = "review_forbidden"
+	ReviewsFetchFailed  Code = "reviews_fetch_failed"
+	ReviewSaveFailed    Code = "review_save_failed"
+
+	ReviewVersionNotFound Code = "review_version_not_found"
+	ReviewHistoryFailed   Code = "review_history_failed"
+)
+
+// Listas de películas
+const (
+	InvalidListID      Code = "invalid_list_id"
+	ListNotFound       Code = "list_not_found"
+	ListNameRequired   Code = "list_name_required"
+	WatchlistImmutable Code = "watchlist_immutable"
+	ListFull           Code = "list_full"
+	InvalidListOrder   Code = "invalid_list_order"
+	ListsFetchFailed   Code = "lists_fetch_failed"
+	ListSaveFailed     Code = "list_save_failed"
+)
+
+// Historial de reproducción
+const (
+	HistoryEntryNotFound Code = "history_entry_not_found"
+	HistoryFetchFailed   Code = "history_fetch_failed"
+	PlaybackSaveFailed   Code = "playback_save_failed"
+)
+
+// Géneros
+const (
+	InvalidGenreID    Code = "invalid_genre_id"
+	GenreNotFound     Code = "genre_not_found"
+	GenreExists       Code = "genre_exists"
+	GenreNameTaken    Code = "genre_name_taken"
+	GenreIDImmutable  Code = "genre_id_immutable"
+	GenreInUse        Code = "genre_in_use"
+	UnknownGenres     Code = "unknown_genres"
+	GenresFetchFailed Code = "genres_fetch_failed"
+	GenreCreateFailed Code = "genre_create_failed"
+	GenreUpdateFailed Code = "genre_update_failed"
+	GenreDeleteFailed Code = "genre_delete_failed"
+)
+
+// Importación y exportación
+const (
+	FormatRequired Code = "format_required"
+	InvalidFormat  Code = "invalid_format"
+	InvalidMode    Code = "invalid_mode"
+	InvalidFile    Code = "invalid_file"
+	FileTooLarge   Code = "file_too_large"
+	ImportFailed   Code = "import_failed"
+)
+
+// entry es el estado HTTP y los mensajes de un código. Los mensajes pueden
+// tener verbos de fmt que se completan con los argumentos del error.
+type entry struct {
+	status   int
+	messages map[string]string
+}
+
+var catalog = map[Code]entry{
+	InternalError:    {http.StatusInternalServerError, msg("Error interno del servidor", "Internal server error")},
+	RouteNotFound:    {http.StatusNotFound, msg("Ruta no encontrada", "Route not found")},
+	InvalidBody:      {http.StatusBadRequest, msg("Datos de entrada inválidos", "Invalid request body")},
+	ValidationFailed: {http.StatusBadRequest, msg("Validación fallida", "Validation failed")},
+
+	InvalidNumber:     {http.StatusBadRequest, msg("El parámetro %s debe ser un número positivo", "Parameter %s must be a positive number")},
+	InvalidBoolean:    {http.StatusBadRequest, msg("El parámetro %s debe ser true o false", "Parameter %s must be true or false")},
+	InvalidRange:      {http.StatusBadRequest, msg("%s no puede ser mayor que %s", "%s cannot be greater than %s")},
+	ParamOutOfRange:   {http.StatusBadRequest, msg("El parámetro %s debe estar entre 1 y %d", "Parameter %s must be between 1 and %d")},
+	InvalidPage:       {http.StatusBadRequest, msg("El parámetro page debe ser un número mayor a 0", "Parameter page must be a number greater than 0")},
+	SearchTooLong:     {http.StatusBadRequest, msg("La búsqueda no puede superar los %d caracteres", "The search cannot be longer than %d characters")},
+	InvalidGenreMatch: {http.StatusBadRequest, msg("genre_match debe ser any o all", "genre_match must be any or all")},
+
+	TokenMissing:          {http.StatusUnauthorized, msg("No se proporcionó token", "No token provided")},
+	TokenInvalid:          {http.StatusUnauthorized, msg("Token inválido o expirado", "Invalid or expired token")},
+	RoleMissing:           {http.StatusBadRequest, msg("No se encontró el rol en el contexto", "Role not found in context")},
+	RoleRequired:          {http.StatusUnauthorized, msg("El usuario debe ser %s", "User must be %s")},
+	InvalidCredentials:    {http.StatusUnauthorized, msg("Correo o contraseña inválidos", "Invalid email or password")},
+	RefreshTokenMissing:   {http.StatusUnauthorized, msg("No se pudo obtener el token de actualización", "Refresh token not provided")},
+	RefreshTokenInvalid:   {http.StatusUnauthorized, msg("Token de actualización inválido o expirado", "Invalid or expired refresh token")},
+	UserNotFound:          {http.StatusUnauthorized, msg("Usuario no encontrado", "User not found")},
+	UserExists:            {http.StatusConflict, msg("El usuario ya existe", "User already exists")},
+	UserCreateFailed:      {http.StatusInternalServerError, msg("Error al crear el usuario", "Could not create the user")},
+	PasswordHashFailed:    {http.StatusInternalServerError, msg("No se pudo encriptar la contraseña", "Could not hash the password")},
+	TokenGenerationFailed: {http.StatusInternalServerError, msg("Error al generar tokens", "Could not generate tokens")},
+	TokenUpdateFailed:     {http.StatusInternalServerError, msg("Error al actualizar tokens", "Could not update tokens")},
+	LogoutFailed:          {http.StatusInternalServerError, msg("Error al cerrar sesión", "Could not log out")},
+
+	MovieIDRequired:     {http.StatusBadRequest, msg("Se requiere el ID de la película", "Movie ID is required")},
+	MovieNotFound:       {http.StatusNotFound, msg("Película no encontrada", "Movie not found")},
+	MovieExists:         {http.StatusConflict, msg("La película ya existe", "Movie already exists")},
+	MoviesFetchFailed:   {http.StatusInternalServerError, msg("Error al obtener las películas", "Could not fetch movies")},
+	MovieCreateFailed:   {http.StatusInternalServerError, msg("No se pudo agregar la película", "Could not add the movie")},
+	MovieUpdateFailed:   {http.StatusInternalServerError, msg("Error al actualizar la película", "Could not update the movie")},
+	MovieSearchFailed:   {http.StatusInternalServerError, msg("Error al buscar películas", "Could not search movies")},
+	SimilarMoviesFailed: {http.StatusInternalServerError, msg("Error al buscar películas parecidas", "Could not find similar movies")},
+	InvalidSort:         {http.StatusBadRequest, msg("Orden no soportado: %s", "Unsupported sort: %s")},
+	InvalidWindow:       {http.StatusBadRequest, msg("Ventana no soportada: %s (hourly, daily o weekly)", "Unsupported window: %s (hourly, daily or weekly)")},
+
+	RecommendationsFailed: {http.StatusInternalServerError, msg("Error al calcular las recomendaciones", "Could not compute recommendations")},
+
+	RatingNotFound: {http.StatusNotFound, msg("No calificaste esta película", "You have not rated this movie")},
+	RatingFailed:   {http.StatusInternalServerError, msg("No se pudo guardar la calificación", "Could not save the rating")},
+
+	InvalidReviewID:     {http.StatusBadRequest, msg("El review_id no es válido", "Invalid review_id")},
+	InvalidReviewStatus: {http.StatusBadRequest, msg("Estado de reseña no soportado: %s", "Unsupported review status: %s")},
+	ReviewNotFound:      {http.StatusNotFound, msg("Reseña no encontrada", "Review not found")},
+	ReviewExists:        {http.StatusConflict, msg("Ya escribiste una reseña de esta película", "You already reviewed this movie")},
+	ReviewForbidden:     {http.StatusForbidden, msg("Solo el autor puede cambiar la reseña", "Only the author can change the review")},
+	ReviewsFetchFailed:  {http.StatusInternalServerError, msg("Error al obtener las reseñas", "Could not fetch reviews")},
+	ReviewSaveFailed:    {http.StatusInternalServerError, msg("No se pudo guardar la reseña", "Could not save the review")},
+
+	ReviewVersionNotFound: {http.StatusNotFound, msg("No existe la versión %d de la reseña", "Review version %d does not exist")},
+	ReviewHistoryFailed:   {http.StatusInternalServerError, msg("Error al obtener el historial de la reseña", "Could not fetch the review history")},
+
+	InvalidListID:      {http.StatusBadRequest, msg("El list_id no es válido", "Invalid list_id")},
+	ListNotFound:       {http.StatusNotFound, msg("Lista no encontrada", "List not found")},
+	ListNameRequired:   {http.StatusBadRequest, msg("La lista necesita un nombre", "The list needs a name")},
+	WatchlistImmutable: {http.StatusBadRequest, msg("La lista de pendientes no se puede renombrar ni borrar", "The watchlist cannot be renamed or deleted")},
+	ListFull:           {http.StatusConflict, msg("La lista no puede tener más de %d películas", "A list cannot have more than %d movies")},
+	InvalidListOrder:   {http.StatusBadRequest, msg("El nuevo orden debe tener exactamente las películas de la lista", "The new order must contain exactly the movies in the list")},
+	ListsFetchFailed:   {http.StatusInternalServerError, msg("Error al obtener las listas", "Could not fetch lists")},
+	ListSaveFailed:     {http.StatusInternalServerError, msg("No se pudo guardar la lista", "Could not save the list")},
+
+	HistoryEntryNotFound: {http.StatusNotFound, msg("La película no está en el historial", "The movie is not in the watch history")},
+	HistoryFetchFailed:   {http.StatusInternalServerError, msg("Error al obtener el historial", "Could not fetch the watch history")},
+	PlaybackSaveFailed:   {http.StatusInternalServerError, msg("No se pudo guardar la reproducción", "Could not save the playback")},
+
+	InvalidGenreID:    {http.StatusBadRequest, msg("El genre_id debe ser un número", "genre_id must be a number")},
+	GenreNotFound:     {http.StatusNotFound, msg("Género no encontrado", "Genre not found")},
+	GenreExists:       {http.StatusConflict, msg("Ya existe un género con ese id o nombre", "A genre with that id or name already exists")},
+	GenreNameTaken:    {http.StatusConflict, msg("Ya existe un género con ese nombre", "A genre with that name already exists")},
+	GenreIDImmutable:  {http.StatusBadRequest, msg("No se puede cambiar el genre_id", "genre_id cannot be changed")},
+	GenreInUse:        {http.StatusConflict, msg("El género está en uso", "The genre is in use")},
+	UnknownGenres:     {http.StatusBadRequest, msg("Géneros inexistentes", "Unknown genres")},
+	GenresFetchFailed: {http.StatusInternalServerError, msg("Error al obtener géneros", "Could not fetch genres")},
+	GenreCreateFailed: {http.StatusInternalServerError, msg("No se pudo crear el género", "Could not create the genre")},
+	GenreUpdateFailed: {http.StatusInternalServerError, msg("No se pudo actualizar el género", "Could not update the genre")},
+	GenreDeleteFailed: {http.StatusInternalServerError, msg("No se pudo borrar el género", "Could not delete the genre")},
+
+	FormatRequired: {http.StatusBadRequest, msg("Se requiere el parámetro format (json, ndjson o csv)", "Parameter format is required (json, ndjson or csv)")},
+	InvalidFormat:  {http.StatusBadRequest, msg("Formato no soportado: %s", "Unsupported format: %s")},
+	InvalidMode:    {http.StatusBadRequest, msg("Modo de importación no soportado: %s", "Unsupported import mode: %s")},
+	InvalidFile:    {http.StatusBadRequest, msg("Archivo inválido", "Invalid file")},
+	FileTooLarge:   {http.StatusRequestEntityTooLarge, msg("El archivo supera el máximo de %d MB", "The file exceeds the %d MB limit")},
+	ImportFailed:   {http.StatusInternalServerError, msg("Error al importar las películas", "Could not import the movies")},
+}
+
+func msg(es, en string) map[string]string {
+	return map[string]string{i18n.Spanish: es, i18n.English: en}
+}
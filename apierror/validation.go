@@ -0,0 +1,84 @@
+package apierror
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describe un campo que no pasó la validación.
+type FieldError struct {
+	// Ruta del campo con los nombres JSON, por ejemplo genre[0].genre_id
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Mensajes por regla de validación. Las reglas min y max dependen del tipo
+// del campo: largo del texto, cantidad de elementos o valor numérico.
+var ruleMessages = map[string]map[string]string{
+	"required":   msg("Es obligatorio", "Is required"),
+	"email":      msg("Debe ser un correo válido", "Must be a valid email"),
+	"url":        msg("Debe ser una URL válida", "Must be a valid URL"),
+	"oneof":      msg("Debe ser uno de: %s", "Must be one of: %s"),
+	"min.string": msg("Debe tener al menos %s caracteres", "Must be at least %s characters long"),
+	"max.string": msg("Debe tener como máximo %s caracteres", "Must be at most %s characters long"),
+	"min.list":   msg("Debe tener al menos %s elementos", "Must have at least %s items"),
+	"max.list":   msg("Debe tener como máximo %s elementos", "Must have at most %s items"),
+	"min":        msg("Debe ser mayor o igual a %s", "Must be greater than or equal to %s"),
+	"max":        msg("Debe ser menor o igual a %s", "Must be less than or equal to %s"),
+}
+
+var unknownRule = msg("No es válido (%s)", "Is not valid (%s)")
+
+func fieldErrors(errs validator.ValidationErrors, locale string) []FieldError {
+	details := make([]FieldError, len(errs))
+	for i, fe := range errs {
+		details[i] = FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: ruleMessage(fe, locale),
+		}
+	}
+	return details
+}
+
+// fieldPath quita el nombre del struct del comienzo de la ruta.
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func ruleMessage(fe validator.FieldError, locale string) string {
+	key := fe.Tag()
+	if key == "min" || key == "max" {
+		switch fe.Kind() {
+		case reflect.String:
+			key += ".string"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			key += ".list"
+		}
+	}
+
+	messages, ok := ruleMessages[key]
+	param := fe.Param()
+	if !ok {
+		messages, param = unknownRule, fe.Tag()
+	}
+	message, ok := messages[locale]
+	if !ok {
+		message = messages[i18n.Default]
+	}
+	if strings.Contains(message, "%s") {
+		message = fmt.Sprintf(message, param)
+	}
+	return message
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/health"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/mailer"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/metrics"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/middleware"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/openapi"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/routes"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/similar"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/suggest"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/tracing"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/trending"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// App es el contenedor de dependencias de la aplicación: configuración,
+// logger, repositorios y servicios compartidos por los handlers.
+type App struct {
+	Config *config.Config
+	Logger *slog.Logger
+	DB     *mongo.Database
+
+	Movies  *repositories.MovieRepository
+	Users   *repositories.UserRepository
+	Genres  *repositories.GenreRepository
+	Ratings *repositories.RatingRepository
+	Reviews *repositories.ReviewRepository
+	Lists   *repositories.ListRepository
+	Watch   *repositories.WatchRepository
+
+	Activity        *repositories.ActivityRepository
+	Recommendations *repositories.RecommendationRepository
+
+	AdminReviews *repositories.AdminReviewRepository
+
+	Suggestions *suggest.Index
+	Similar     *similar.Recommender
+	Trending    *trending.Job
+
+	Tokens  *utils.TokenService
+	Mailer  mailer.Mailer
+	Metrics *metrics.Metrics
+	Health  *health.Checker
+
+	jobs sync.WaitGroup
+}
+
+// New construye la aplicación sobre la base de datos indicada. m son las
+// métricas ya conectadas al cliente de MongoDB; si es nil se crean nuevas,
+// sin las de MongoDB. Los campos exportados pueden reemplazarse (por ejemplo
+// en pruebas) antes de llamar a Router.
+func New(cfg *config.Config, db *mongo.Database, m *metrics.Metrics) *App {
+	if m == nil {
+		m = metrics.New()
+	}
+
+	logger := logging.New(os.Stdout, cfg.LogLevel)
+	movies := repositories.NewMovieRepository(db)
+
+	suggestions := suggest.NewIndex(movies, logger)
+	movies.OnChange(suggestions.Invalidate)
+
+	recommender := similar.NewRecommender(movies)
+	movies.OnChange(recommender.Invalidate)
+
+	activity := repositories.NewActivityRepository(db)
+	popularity := trending.NewJob(activity, movies, logger, nil)
+
+	genres := repositories.NewGenreRepository(db)
+	genres.OnChange(recommender.Invalidate)
+
+	return &App{
+		Config:          cfg,
+		Logger:          logger,
+		DB:              db,
+		Movies:          movies,
+		Users:           repositories.NewUserRepository(db),
+		Genres:          genres,
+		Ratings:         repositories.NewRatingRepository(db),
+		Reviews:         repositories.NewReviewRepository(db),
+		AdminReviews:    repositories.NewAdminReviewRepository(db, movies),
+		Lists:           repositories.NewListRepository(db),
+		Watch:           repositories.NewWatchRepository(db),
+		Activity:        activity,
+		Recommendations: repositories.NewRecommendationRepository(db),
+		Suggestions:     suggestions,
+		Similar:         recommender,
+		Trending:        popularity,
+		Tokens:          utils.NewTokenService(cfg.SecretKey, cfg.SecretRefreshKey),
+		Mailer:          mailer.NewLogMailer(cfg.MailFrom, logger),
+		Metrics:         m,
+		Health:          health.NewChecker(cfg, db),
+	}
+}
+
+// Start lanza los trabajos en segundo plano (índices en memoria, etc.).
+// Terminan cuando se cancela el contexto; Wait espera a que terminen.
+func (a *App) Start(ctx context.Context) {
+	a.jobs.Go(func() { a.Suggestions.Run(ctx) })
+	a.jobs.Go(func() { a.Trending.Run(ctx) })
+}
+
+// Wait bloquea hasta que terminan los trabajos lanzados por Start.
+func (a *App) Wait() {
+	a.jobs.Wait()
+}
+
+// Router construye el gin.Engine con el middleware y todas las rutas.
+func (a *App) Router() *gin.Engine {
+	router := gin.New()
+	// Los handlers usan c como contexto: así heredan el span y la cancelación del pedido
+	router.ContextWithFallback = true
+	router.Use(tracing.Middleware())
+	router.Use(middleware.RequestLogger(a.Logger))
+	router.Use(a.Metrics.Middleware())
+	router.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logging.FromContext(c).Error("panic", "panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+		apierror.Render(c, apierror.New(apierror.InternalError))
+	}))
+
+	router.GET("/metrics", a.Metrics.Handler(a.Config.MetricsToken))
+	router.GET("/healthz", health.Liveness())
+	router.GET("/readyz", a.Health.Readiness())
+	router.GET("/version", buildinfo.Handler())
+	router.GET(openAPIPath, openapi.Handler(openapi.Build(APIDocs())))
+	router.GET(docsPath, openapi.UIHandler("PeliculApp API", openAPIPath))
+
+	router.GET("/hello", func(c *gin.Context) {
+		c.String(200, "Hello, PeliculApp!")
+	})
+
+	a.Logger.Info("orígenes permitidos", "origins", a.Config.AllowedOrigins)
+
+	config := cors.Config{
+		AllowOrigins:     a.Config.AllowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "PATCH", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", middleware.RequestIDHeader, "Deprecation", "Sunset", "Link"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+	router.Use(cors.New(config))
+	router.Use(middleware.Locale())
+
+	handlers := routes.Handlers{
+		Auth:            middleware.AuthMiddleWare(a.Tokens, a.Metrics),
+		Identify:        middleware.OptionalAuth(a.Tokens),
+		Movies:          controller.NewMovieController(a.Movies, a.Genres, a.Suggestions, a.Similar, a.Ratings, a.Lists, a.Watch, a.Activity),
+		Genres:          controller.NewGenreController(a.Genres),
+		Users:           controller.NewUserController(a.Users, a.Genres, a.Tokens, a.Metrics),
+		Bulk:            controller.NewBulkController(a.Movies, a.Genres),
+		Ratings:         controller.NewRatingController(a.Ratings, a.Activity),
+		Reviews:         controller.NewReviewController(a.Reviews, a.Movies, a.Users),
+		AdminReviews:    controller.NewAdminReviewController(a.AdminReviews, a.Users),
+		Lists:           controller.NewListController(a.Lists, a.Movies, a.Activity),
+		Watch:           controller.NewWatchController(a.Watch, a.Movies),
+		Recommendations: controller.NewRecommendationController(a.Recommendations, a.Movies, a.Users),
+	}
+	routes.SetupV1(router, handlers)
+	routes.SetupLegacyRoutes(router, handlers, a.Config.LegacySunset)
+
+	router.NoRoute(func(c *gin.Context) {
+		apierror.Render(c, apierror.New(apierror.RouteNotFound))
+	})
+
+	// Toda ruta nueva necesita su descripción en controllers.APIDocs
+	undocumented, unknown := CheckDocs(router)
+	for _, route := range undocumented {
+		a.Logger.Warn("ruta sin documentar en OpenAPI", "route", route)
+	}
+	for _, route := range unknown {
+		a.Logger.Warn("ruta documentada en OpenAPI que no existe", "route", route)
+	}
+
+	return router
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo"
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/health"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/openapi"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/routes"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	tagSystem = "Sistema"
+	tagLegacy = "Rutas anteriores"
+)
+
+// Rutas del documento OpenAPI y de la página de Swagger UI
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+)
+
+// APIDocs describe todas las rutas que registra Router: las de los
+// controladores en /api/v1, sus alias anteriores y las del sistema.
+func APIDocs() openapi.Spec {
+	spec := controller.APIDocs()
+	spec.Routes = versioned(spec.Routes)
+	spec.Info = openapi.Info{
+		Title:       "PeliculApp API",
+		Version:     buildinfo.Get().Version,
+		Description: "Catálogo de películas, reseñas, listas e historial de PeliculApp. Los errores usan el sobre de apierror: {\"error\": {\"code\", \"message\", ...}}.",
+	}
+	spec.Tags = append(spec.Tags,
+		openapi.Tag{Name: tagSystem, Description: "Sondas, métricas y documentación"},
+		openapi.Tag{Name: tagLegacy, Description: "Rutas sin versión, obsoletas; responden con los encabezados Deprecation, Sunset y Link a su reemplazo en /api/v1"},
+	)
+	spec.Names = append(spec.Names,
+		openapi.NameOf[health.Report]("HealthReport"),
+		openapi.NameOf[health.Check]("HealthCheck"),
+		openapi.NameOf[buildinfo.Info]("BuildInfo"),
+	)
+	spec.Routes = append(spec.Routes,
+		openapi.Route{
+			Method: http.MethodGet, Path: "/metrics", ID: "getMetrics", Tag: tagSystem,
+			Summary: "Métricas en formato Prometheus", Auth: openapi.MetricsToken,
+			ResponseContent: map[string]*openapi.Schema{"text/plain": openapi.Binary()},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: "/healthz", ID: "getLiveness", Tag: tagSystem,
+			Summary: "Sonda de vida", Response: health.Report{},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: "/readyz", ID: "getReadiness", Tag: tagSystem,
+			Summary:       "Sonda de disponibilidad: configuración, MongoDB e índices",
+			Response:      health.Report{},
+			MoreResponses: map[int]any{http.StatusServiceUnavailable: health.Report{}},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: "/version", ID: "getVersion", Tag: tagSystem,
+			Summary: "Versión y commit del binario", Response: buildinfo.Info{},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: "/hello", ID: "hello", Tag: tagSystem,
+			Summary:         "Saludo de prueba",
+			ResponseContent: map[string]*openapi.Schema{"text/plain": openapi.String()},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: openAPIPath, ID: "getOpenAPI", Tag: tagSystem,
+			Summary:         "Este documento",
+			ResponseContent: map[string]*openapi.Schema{"application/json": {Type: "object"}},
+		},
+		openapi.Route{
+			Method: http.MethodGet, Path: docsPath, ID: "getDocs", Tag: tagSystem,
+			Summary:         "Swagger UI",
+			ResponseContent: map[string]*openapi.Schema{"text/html": openapi.String()},
+		},
+	)
+	return spec
+}
+
+// versioned ubica las rutas bajo /api/v1 y agrega, marcada como obsoleta,
+// la ruta anterior de cada una.
+func versioned(docs []openapi.Route) []openapi.Route {
+	all := make([]openapi.Route, 0, 2*len(docs))
+	for _, doc := range docs {
+		legacy := doc
+		legacy.Method, legacy.Path = routes.LegacyRoute(doc.Method, doc.Path)
+		legacy.ID = doc.ID + "Legacy"
+		legacy.Tag = tagLegacy
+		legacy.Description = strings.TrimSpace("Obsoleta: usar " + doc.Method + " " + openapi.Path(routes.V1Prefix+doc.Path) + ". " + doc.Description)
+		legacy.Deprecated = true
+
+		doc.Path = routes.V1Prefix + doc.Path
+		all = append(all, doc, legacy)
+	}
+	return all
+}
+
+// CheckDocs compara las rutas del router con APIDocs. Devuelve las rutas
+// registradas sin documentar y las documentadas que el router no tiene.
+func CheckDocs(router *gin.Engine) (undocumented, unknown []string) {
+	return openapi.Compare(router.Routes(), APIDocs().Routes)
+}
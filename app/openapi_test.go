@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/openapi"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Toda ruta del router tiene que estar en APIDocs y al revés. El router se
+// arma con un cliente que nunca se conecta: armar las rutas no consulta la base.
+func TestRoutesAreDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client, err := mongo.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost:5173"}}
+	application := New(cfg, client.Database("peliculapp_test"), nil)
+	application.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	undocumented, unknown := CheckDocs(application.Router())
+	for _, route := range undocumented {
+		t.Errorf("ruta sin documentar en controllers.APIDocs: %s", route)
+	}
+	for _, route := range unknown {
+		t.Errorf("ruta documentada que el router no registra: %s", route)
+	}
+}
+
+// Los operationId son únicos y cada $ref apunta a un componente que existe.
+func TestAPIDocsReferences(t *testing.T) {
+	doc := openapi.Build(APIDocs())
+
+	operations := map[string]string{}
+	for path, methods := range doc.Paths {
+		for method, operation := range methods {
+			route := strings.ToUpper(method) + " " + path
+			if other, ok := operations[operation.OperationID]; ok {
+				t.Errorf("operationId %q repetido en %s y %s", operation.OperationID, other, route)
+			}
+			operations[operation.OperationID] = route
+		}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range refs(tree) {
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if ok && doc.Components.Schemas[name] != nil {
+			continue
+		}
+		name, ok = strings.CutPrefix(ref, "#/components/responses/")
+		if _, found := doc.Components.Responses[name]; ok && found {
+			continue
+		}
+		t.Errorf("$ref sin destino: %s", ref)
+	}
+}
+
+// refs junta los valores de todas las claves $ref del documento.
+func refs(node any) []string {
+	var found []string
+	switch value := node.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				found = append(found, ref)
+				continue
+			}
+			found = append(found, refs(child)...)
+		}
+	case []any:
+		for _, child := range value {
+			found = append(found, refs(child)...)
+		}
+	}
+	return found
+}
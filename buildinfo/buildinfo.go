@@ -0,0 +1,61 @@
+// Package buildinfo expone la versión del binario. Los valores se fijan al
+// compilar con -ldflags, por ejemplo:
+//
+//	go build -ldflags "-X github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo.Version=1.4.0 \
+//	  -X github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Fijados con -ldflags "-X"; sin ellos el binario se identifica como "dev"
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describe el binario en ejecución.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get devuelve la información de compilación. Si no se pasó el commit por
+// ldflags se usa el que Go registra al compilar dentro de un repositorio git.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+	if info.Commit != "" {
+		return info
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.revision" {
+				info.Commit = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+// Handler responde con la información de compilación en JSON.
+func Handler() gin.HandlerFunc {
+	info := Get()
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, info)
+	}
+}
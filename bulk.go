@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/bulk"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+)
+
+func runImport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	formatName := flags.String("format", "", "json, ndjson o csv (por defecto según la extensión)")
+	modeName := flags.String("mode", string(bulk.ModeBestEffort), "best-effort o all-or-nothing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("uso: import [--format f] [--mode m] <archivo>")
+	}
+	path := flags.Arg(0)
+
+	format, err := cliFormat(*formatName, path)
+	if err != nil {
+		return err
+	}
+	mode, err := bulk.ParseMode(*modeName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := bulk.NewMovieReader(file, format)
+	if err != nil {
+		return err
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	db := client.Database(cfg.DatabaseName)
+	importer := bulk.NewImporter(repositories.NewMovieRepository(db), repositories.NewGenreRepository(db))
+	report, importErr := importer.Import(ctx, reader, mode)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	if importErr != nil {
+		return importErr
+	}
+	if !report.Committed {
+		return fmt.Errorf("importación cancelada: %d filas con errores", report.Failed)
+	}
+	return nil
+}
+
+func runExport(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	formatName := flags.String("format", "", "json, ndjson o csv (por defecto según --out, o json)")
+	out := flags.String("out", "", "archivo de salida (por defecto la salida estándar)")
+	text := flags.String("query", "", "exportar solo las películas que coincidan con la búsqueda")
+	genres := flags.String("genres", "", "exportar solo estos géneros (ids o nombres separados por coma)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := cliFormat(*formatName, *out)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	writer, err := bulk.NewMovieWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	movies := repositories.NewMovieRepository(client.Database(cfg.DatabaseName))
+	query := repositories.MovieQuery{Text: *text, GenreMatch: repositories.MatchAny}
+	for _, genre := range strings.Split(*genres, ",") {
+		if genre = strings.TrimSpace(genre); genre != "" {
+			query.Genres = append(query.Genres, genre)
+		}
+	}
+	if err := movies.ForEach(ctx, query, func(movie models.Movie) error {
+		return writer.Write(movie)
+	}); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// cliFormat usa el formato indicado o lo deduce de la extensión del archivo.
+func cliFormat(name, path string) (bulk.Format, error) {
+	if name != "" {
+		return bulk.ParseFormat(name)
+	}
+	if path == "" {
+		return bulk.FormatJSON, nil
+	}
+	return bulk.FormatFromPath(path)
+}
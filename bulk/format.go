@@ -0,0 +1,58 @@
+package bulk
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Format es el formato de archivo para importar o exportar películas.
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+// ParseFormat interpreta el nombre de un formato.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "json":
+		return FormatJSON, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("formato no soportado: %s", name)
+}
+
+// FormatFromPath deduce el formato a partir de la extensión del archivo.
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// FormatFromContentType deduce el formato a partir del Content-Type.
+func FormatFromContentType(contentType string) (Format, bool) {
+	switch {
+	case strings.Contains(contentType, "ndjson"), strings.Contains(contentType, "jsonlines"):
+		return FormatNDJSON, true
+	case strings.Contains(contentType, "csv"):
+		return FormatCSV, true
+	case strings.Contains(contentType, "json"):
+		return FormatJSON, true
+	}
+	return "", false
+}
+
+// ContentType devuelve el tipo MIME del formato.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
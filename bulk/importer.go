@@ -0,0 +1,187 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+)
+
+// Mode define qué pasa cuando alguna fila es inválida.
+type Mode string
+
+const (
+	// ModeBestEffort guarda las filas válidas y reporta las inválidas.
+	ModeBestEffort Mode = "best-effort"
+	// ModeAllOrNothing no guarda nada si alguna fila es inválida y escribe
+	// todo dentro de una transacción.
+	ModeAllOrNothing Mode = "all-or-nothing"
+)
+
+// ParseMode interpreta el nombre de un modo. El valor vacío es best-effort.
+func ParseMode(name string) (Mode, error) {
+	switch Mode(name) {
+	case "", ModeBestEffort:
+		return ModeBestEffort, nil
+	case ModeAllOrNothing:
+		return ModeAllOrNothing, nil
+	}
+	return "", fmt.Errorf("modo de importación no soportado: %s", name)
+}
+
+// ErrMalformed indica que el archivo no se pudo seguir leyendo.
+var ErrMalformed = errors.New("archivo mal formado")
+
+// Cantidad de películas por escritura masiva en modo best-effort
+const batchSize = 500
+
+// RowError describe una fila que no se pudo importar. Row empieza en 1.
+type RowError struct {
+	Row    int    `json:"row"`
+	ImdbID string `json:"imdb_id,omitempty"`
+	Error  string `json:"error"`
+}
+
+// ImportReport es el resultado de una importación.
+type ImportReport struct {
+	Mode      Mode       `json:"mode"`
+	Total     int        `json:"total"`
+	Inserted  int        `json:"inserted"`
+	Updated   int        `json:"updated"`
+	Unchanged int        `json:"unchanged"`
+	Failed    int        `json:"failed"`
+	Committed bool       `json:"committed"`
+	Errors    []RowError `json:"errors"`
+}
+
+type Importer struct {
+	movies *repositories.MovieRepository
+	genres *repositories.GenreRepository
+}
+
+func NewImporter(movies *repositories.MovieRepository, genres *repositories.GenreRepository) *Importer {
+	return &Importer{movies: movies, genres: genres}
+}
+
+type pendingRow struct {
+	row   int
+	movie models.Movie
+}
+
+// Import lee todas las filas del lector, las valida y las inserta o actualiza
+// por imdb_id. Los errores por fila quedan en el reporte; el error devuelto
+// indica un fallo que impidió completar la importación.
+func (im *Importer) Import(ctx context.Context, reader MovieReader, mode Mode) (*ImportReport, error) {
+	report := &ImportReport{Mode: mode, Errors: []RowError{}}
+	seen := map[string]int{}
+
+	catalog, err := im.genres.Catalog(ctx)
+	if err != nil {
+		return report, err
+	}
+	var batch []pendingRow
+
+	for row := 1; ; row++ {
+		movie, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		report.Total++
+
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			report.fail(row, movie.ImdbID, err)
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("fila %d: %w: %w", row, ErrMalformed, err)
+		}
+
+		if err := models.Validate(movie); err != nil {
+			report.fail(row, movie.ImdbID, err)
+			continue
+		}
+		if movie.Genre, err = catalog.Resolve(movie.Genre); err != nil {
+			report.fail(row, movie.ImdbID, err)
+			continue
+		}
+		if first, ok := seen[movie.ImdbID]; ok {
+			report.fail(row, movie.ImdbID, fmt.Errorf("imdb_id repetido, ya aparece en la fila %d", first))
+			continue
+		}
+		seen[movie.ImdbID] = row
+
+		batch = append(batch, pendingRow{row: row, movie: movie})
+		if mode == ModeBestEffort && len(batch) >= batchSize {
+			if err := im.write(ctx, report, batch); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if mode == ModeAllOrNothing {
+		if report.Failed > 0 {
+			return report, nil
+		}
+		err := im.movies.WithTransaction(ctx, func(ctx context.Context) error {
+			// La transacción puede reintentarse: cada intento empieza de cero.
+			report.reset()
+			return im.write(ctx, report, batch)
+		})
+		if err != nil {
+			report.Inserted, report.Updated, report.Unchanged = 0, 0, 0
+			if report.Failed > 0 {
+				return report, nil
+			}
+			return report, err
+		}
+		report.Committed = true
+		return report, nil
+	}
+
+	if err := im.write(ctx, report, batch); err != nil {
+		return report, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// write guarda un lote y suma sus resultados al reporte. En modo
+// all-or-nothing cualquier error de escritura aborta la transacción.
+func (im *Importer) write(ctx context.Context, report *ImportReport, batch []pendingRow) error {
+	movies := make([]models.Movie, len(batch))
+	for i, pending := range batch {
+		movies[i] = pending.movie
+	}
+
+	result, err := im.movies.UpsertMany(ctx, movies)
+	if err != nil {
+		return err
+	}
+
+	for i, writeErr := range result.Errors {
+		report.fail(batch[i].row, batch[i].movie.ImdbID, writeErr)
+	}
+	if report.Mode == ModeAllOrNothing && len(result.Errors) > 0 {
+		return fmt.Errorf("%d filas no se pudieron guardar", len(result.Errors))
+	}
+
+	report.Inserted += result.Inserted
+	report.Updated += result.Updated
+	report.Unchanged += result.Unchanged
+	return nil
+}
+
+func (r *ImportReport) fail(row int, imdbID string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, RowError{Row: row, ImdbID: imdbID, Error: err.Error()})
+}
+
+func (r *ImportReport) reset() {
+	r.Inserted, r.Updated, r.Unchanged, r.Failed = 0, 0, 0, 0
+	r.Errors = []RowError{}
+}
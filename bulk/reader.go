@@ -0,0 +1,220 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Columnas del formato CSV. Los géneros se escriben como "id:nombre|id:nombre".
+// title_en y description_en son la traducción al inglés.
+var csvColumns = []string{
+	"imdb_id", "title", "poster_path", "youtube_id", "genres",
+	"admin_review", "description", "watch_url", "ranking_value", "ranking_name",
+	"release_year", "title_en", "description_en",
+}
+
+// ParseError indica que una fila no se pudo interpretar. La lectura puede
+// continuar con la siguiente fila.
+type ParseError struct {
+	Err error
+}
+
+func (e *ParseError) Error() string { return e.Err.Error() }
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// MovieReader lee películas de una en una.
+type MovieReader interface {
+	// Next devuelve la siguiente película, io.EOF al terminar o *ParseError
+	// si la fila actual es inválida. Cualquier otro error es definitivo.
+	Next() (models.Movie, error)
+}
+
+// NewMovieReader crea un lector para el formato indicado.
+func NewMovieReader(r io.Reader, format Format) (MovieReader, error) {
+	switch format {
+	case FormatJSON:
+		return newJSONReader(r)
+	case FormatNDJSON:
+		return newNDJSONReader(r), nil
+	case FormatCSV:
+		return newCSVReader(r)
+	}
+	return nil, fmt.Errorf("formato no soportado: %s", format)
+}
+
+// jsonReader lee un arreglo JSON elemento por elemento.
+type jsonReader struct {
+	decoder *json.Decoder
+}
+
+func newJSONReader(r io.Reader) (*jsonReader, error) {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, fmt.Errorf("JSON inválido: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("se esperaba un arreglo JSON de películas")
+	}
+	return &jsonReader{decoder: decoder}, nil
+}
+
+func (jr *jsonReader) Next() (models.Movie, error) {
+	var movie models.Movie
+	if !jr.decoder.More() {
+		return movie, io.EOF
+	}
+	err := jr.decoder.Decode(&movie)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return movie, &ParseError{Err: err}
+	}
+	return movie, err
+}
+
+// ndjsonReader lee una película por línea.
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+}
+
+func newNDJSONReader(r io.Reader) *ndjsonReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &ndjsonReader{scanner: scanner}
+}
+
+func (nr *ndjsonReader) Next() (models.Movie, error) {
+	var movie models.Movie
+	for nr.scanner.Scan() {
+		line := strings.TrimSpace(nr.scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(line), &movie); err != nil {
+			return movie, &ParseError{Err: err}
+		}
+		return movie, nil
+	}
+	if err := nr.scanner.Err(); err != nil {
+		return movie, err
+	}
+	return movie, io.EOF
+}
+
+// csvReader lee películas de un CSV con encabezado.
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer el encabezado CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	if _, ok := columns["imdb_id"]; !ok {
+		return nil, errors.New("el CSV debe tener la columna imdb_id")
+	}
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (cr *csvReader) Next() (models.Movie, error) {
+	var movie models.Movie
+	record, err := cr.reader.Read()
+	if err == io.EOF {
+		return movie, io.EOF
+	}
+	var csvErr *csv.ParseError
+	if errors.As(err, &csvErr) {
+		return movie, &ParseError{Err: err}
+	}
+	if err != nil {
+		return movie, err
+	}
+
+	get := func(name string) string {
+		if i, ok := cr.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	movie.ImdbID = get("imdb_id")
+	movie.Title = get("title")
+	movie.PosterPath = get("poster_path")
+	movie.YouTubeID = get("youtube_id")
+	movie.AdminReview = get("admin_review")
+	movie.Description = get("description")
+	movie.WatchURL = get("watch_url")
+	movie.Ranking.RankingName = get("ranking_name")
+
+	if title, description := get("title_en"), get("description_en"); title != "" || description != "" {
+		movie.Translations = map[string]models.MovieTranslation{
+			i18n.English: {Title: title, Description: description},
+		}
+	}
+
+	if value := get("ranking_value"); value != "" {
+		movie.Ranking.RankingValue, err = strconv.Atoi(value)
+		if err != nil {
+			return movie, &ParseError{Err: fmt.Errorf("ranking_value inválido: %s", value)}
+		}
+	}
+
+	if value := get("release_year"); value != "" {
+		movie.ReleaseYear, err = strconv.Atoi(value)
+		if err != nil {
+			return movie, &ParseError{Err: fmt.Errorf("release_year inválido: %s", value)}
+		}
+	}
+
+	movie.Genre, err = parseGenres(get("genres"))
+	if err != nil {
+		return movie, &ParseError{Err: err}
+	}
+	return movie, nil
+}
+
+func parseGenres(value string) ([]models.Genre, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var genres []models.Genre
+	for _, part := range strings.Split(value, "|") {
+		id, name, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("género inválido: %q (se espera id:nombre)", part)
+		}
+		genreID, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			return nil, fmt.Errorf("genre_id inválido: %q", id)
+		}
+		genres = append(genres, models.Genre{GenreID: genreID, GenreName: strings.TrimSpace(name)})
+	}
+	return genres, nil
+}
+
+func formatGenres(genres []models.Genre) string {
+	parts := make([]string, len(genres))
+	for i, genre := range genres {
+		parts[i] = strconv.Itoa(genre.GenreID) + ":" + genre.GenreName
+	}
+	return strings.Join(parts, "|")
+}
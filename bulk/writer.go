@@ -0,0 +1,121 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// MovieWriter escribe películas de una en una. Close completa el documento
+// (por ejemplo cierra el arreglo JSON) y debe llamarse siempre.
+type MovieWriter interface {
+	Write(movie models.Movie) error
+	Close() error
+}
+
+// NewMovieWriter crea un escritor para el formato indicado.
+func NewMovieWriter(w io.Writer, format Format) (MovieWriter, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("formato no soportado: %s", format)
+}
+
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (jw *jsonWriter) Write(movie models.Movie) error {
+	prefix := ",\n"
+	if jw.count == 0 {
+		prefix = "[\n"
+	}
+	data, err := json.Marshal(movie)
+	if err != nil {
+		return err
+	}
+	jw.count++
+	if _, err := io.WriteString(jw.w, prefix); err != nil {
+		return err
+	}
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	closing := "\n]\n"
+	if jw.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, closing)
+	return err
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(movie models.Movie) error {
+	return nw.encoder.Encode(movie)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (cw *csvWriter) Write(movie models.Movie) error {
+	if !cw.wroteHeader {
+		if err := cw.writer.Write(csvColumns); err != nil {
+			return err
+		}
+		cw.wroteHeader = true
+	}
+	return cw.writer.Write([]string{
+		movie.ImdbID,
+		movie.Title,
+		movie.PosterPath,
+		movie.YouTubeID,
+		formatGenres(movie.Genre),
+		movie.AdminReview,
+		movie.Description,
+		movie.WatchURL,
+		strconv.Itoa(movie.Ranking.RankingValue),
+		movie.Ranking.RankingName,
+		releaseYear(movie.ReleaseYear),
+		movie.Translations[i18n.English].Title,
+		movie.Translations[i18n.English].Description,
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	if !cw.wroteHeader {
+		if err := cw.writer.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+func releaseYear(year int) string {
+	if year == 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
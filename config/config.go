@@ -0,0 +1,156 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/joho/godotenv"
+)
+
+// Config reúne la configuración de la aplicación leída del entorno.
+type Config struct {
+	Port             string
+	MongoURI         string
+	DatabaseName     string
+	AllowedOrigins   []string
+	SecretKey        string
+	SecretRefreshKey string
+	MailFrom         string
+	MigrateOnStart   bool
+	// Nivel mínimo de los logs (LOG_LEVEL: debug, info, warn o error)
+	LogLevel slog.Level
+	// Token que exige /metrics; vacío deja el endpoint abierto
+	MetricsToken string
+	// Exportador de trazas (OTEL_TRACES_EXPORTER: otlp, stdout o none) y
+	// nombre del servicio en las trazas (OTEL_SERVICE_NAME)
+	TracesExporter string
+	ServiceName    string
+	// Límites del servidor HTTP (HTTP_READ_TIMEOUT, HTTP_WRITE_TIMEOUT, HTTP_IDLE_TIMEOUT)
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// Al apagarse, tiempo que /readyz falla antes de dejar de aceptar conexiones
+	// (SHUTDOWN_DELAY) y plazo para terminar los pedidos en curso (SHUTDOWN_TIMEOUT)
+	ShutdownDelay   time.Duration
+	ShutdownTimeout time.Duration
+	// Fecha en que se retiran las rutas anteriores a /api/v1 (LEGACY_API_SUNSET,
+	// AAAA-MM-DD); se anuncia en el encabezado Sunset
+	LegacySunset time.Time
+}
+
+// Load carga el archivo .env (si existe) y construye la configuración.
+func Load() (*Config, error) {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Println("Advertencia: no se encontró el archivo .env")
+	}
+
+	cfg := &Config{
+		Port:             getEnv("PORT", "8080"),
+		MongoURI:         os.Getenv("MONGODB_URI"),
+		DatabaseName:     os.Getenv("DATABASE_NAME"),
+		AllowedOrigins:   splitList(getEnv("ALLOWED_ORIGINS", "http://localhost:5173")),
+		SecretKey:        os.Getenv("SECRET_KEY"),
+		SecretRefreshKey: os.Getenv("SECRET_REFRESH_KEY"),
+		MailFrom:         getEnv("MAIL_FROM", "no-reply@peliculapp.local"),
+		MigrateOnStart:   getEnv("MIGRATE_ON_START", "true") == "true",
+		MetricsToken:     os.Getenv("METRICS_TOKEN"),
+		TracesExporter:   getEnv("OTEL_TRACES_EXPORTER", "none"),
+		ServiceName:      getEnv("OTEL_SERVICE_NAME", "peliculapp"),
+	}
+
+	level, err := logging.ParseLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.LogLevel = level
+
+	durations := []struct {
+		target   *time.Duration
+		key      string
+		fallback time.Duration
+	}{
+		{&cfg.ReadTimeout, "HTTP_READ_TIMEOUT", 30 * time.Second},
+		{&cfg.WriteTimeout, "HTTP_WRITE_TIMEOUT", 60 * time.Second},
+		{&cfg.IdleTimeout, "HTTP_IDLE_TIMEOUT", 120 * time.Second},
+		{&cfg.ShutdownDelay, "SHUTDOWN_DELAY", 5 * time.Second},
+		{&cfg.ShutdownTimeout, "SHUTDOWN_TIMEOUT", 20 * time.Second},
+	}
+	for _, d := range durations {
+		if *d.target, err = getDuration(d.key, d.fallback); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.LegacySunset, err = getDate("LEGACY_API_SUNSET", "2027-04-30"); err != nil {
+		return nil, err
+	}
+
+	if cfg.MongoURI == "" {
+		return nil, errors.New("MONGODB_URI no está definido en el archivo .env")
+	}
+	if cfg.DatabaseName == "" {
+		return nil, errors.New("DATABASE_NAME no está definido en el archivo .env")
+	}
+
+	return cfg, nil
+}
+
+// Missing lista las variables necesarias para atender pedidos que no están
+// definidas. Load no las exige para que los comandos de consola funcionen sin ellas.
+func (c *Config) Missing() []string {
+	var missing []string
+	if c.SecretKey == "" {
+		missing = append(missing, "SECRET_KEY")
+	}
+	if c.SecretRefreshKey == "" {
+		missing = append(missing, "SECRET_REFRESH_KEY")
+	}
+	if len(c.AllowedOrigins) == 0 {
+		missing = append(missing, "ALLOWED_ORIGINS")
+	}
+	return missing
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s no es una duración válida (por ejemplo 30s): %q", key, value)
+	}
+	return d, nil
+}
+
+func getDate(key, fallback string) (time.Time, error) {
+	value := getEnv(key, fallback)
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s no es una fecha válida (AAAA-MM-DD): %q", key, value)
+	}
+	return date, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,18 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// recordActivity guarda un evento para el cálculo de popularidad, con el
+// usuario si tiene sesión. La popularidad tolera eventos perdidos, así que un
+// error al guardarlo no hace fallar el pedido.
+func recordActivity(ctx context.Context, c *gin.Context, activity *repositories.ActivityRepository, imdbID string, kind models.ActivityKind) {
+	userID, _ := utils.GetUserIdFromContext(c)
+	_ = activity.Record(ctx, models.ActivityEvent{UserID: userID, ImdbID: imdbID, Kind: kind})
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminReviewController struct {
+	reviews *repositories.AdminReviewRepository
+	users   *repositories.UserRepository
+}
+
+func NewAdminReviewController(reviews *repositories.AdminReviewRepository, users *repositories.UserRepository) *AdminReviewController {
+	return &AdminReviewController{reviews: reviews, users: users}
+}
+
+// Cuerpo para cambiar la reseña del administrador
+type adminReviewRequest struct {
+	AdminReview string `json:"admin_review"`
+}
+
+// Respuesta al cambiar la reseña: el texto nuevo y su número de versión
+type adminReviewResponse struct {
+	AdminReview string `json:"admin_review"`
+	Version     int    `json:"version"`
+}
+
+// Cuerpo para volver a una versión anterior de la reseña
+type revertRequest struct {
+	Version int `json:"version" validate:"required,min=1"`
+}
+
+func (ac *AdminReviewController) AdminReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, err := utils.GetRoleFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RoleMissing))
+			return
+		}
+
+		if role != "ADMIN" {
+			apierror.Render(c, apierror.New(apierror.RoleRequired, "ADMIN"))
+			return
+		}
+
+		movieID := c.Param("imdb_id")
+		if movieID == "" {
+			apierror.Render(c, apierror.New(apierror.MovieIDRequired))
+			return
+		}
+
+		var req adminReviewRequest
+		if err := c.ShouldBind(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		version, err := ac.reviews.Update(ctx, movieID, req.AdminReview, ac.author(ctx, c), 0)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.MovieUpdateFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, adminReviewResponse{AdminReview: req.AdminReview, Version: version.Version})
+	}
+}
+
+// Historial de versiones de la reseña del administrador, la más nueva primero
+func (ac *AdminReviewController) ReviewHistory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, pageSize, err := paginationParams(c)
+		if err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		history, err := ac.reviews.History(ctx, c.Param("imdb_id"), page, pageSize)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewHistoryFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, history)
+	}
+}
+
+// Volver a una versión anterior de la reseña. La reversión se guarda como una
+// versión nueva, así el historial no pierde nada.
+func (ac *AdminReviewController) RevertReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req revertRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		movieID := c.Param("imdb_id")
+		target, err := ac.reviews.FindVersion(ctx, movieID, req.Version)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ReviewVersionNotFound, req.Version))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewHistoryFailed))
+			return
+		}
+
+		version, err := ac.reviews.Update(ctx, movieID, target.Text, ac.author(ctx, c), target.Version)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.MovieUpdateFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, version)
+	}
+}
+
+// author identifica al administrador del pedido. Si no se encuentra el
+// usuario se guarda solo su id.
+func (ac *AdminReviewController) author(ctx context.Context, c *gin.Context) repositories.AdminReviewAuthor {
+	userID, _ := utils.GetUserIdFromContext(c)
+	author := repositories.AdminReviewAuthor{ID: userID}
+	if user, err := ac.users.FindByUserID(ctx, userID); err == nil {
+		author.Name = user.FirstName + " " + user.LastName
+	}
+	return author
+}
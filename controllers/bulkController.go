@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/bulk"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// Tamaño máximo del archivo de una importación
+const maxImportSize = 50 << 20
+
+// Plazo de una importación o exportación completa. Reemplaza los límites de
+// lectura y escritura del servidor, pensados para pedidos cortos.
+const bulkTimeout = 10 * time.Minute
+
+type BulkController struct {
+	movies   *repositories.MovieRepository
+	importer *bulk.Importer
+}
+
+func NewBulkController(movies *repositories.MovieRepository, genres *repositories.GenreRepository) *BulkController {
+	return &BulkController{movies: movies, importer: bulk.NewImporter(movies, genres)}
+}
+
+// Importar películas en JSON, NDJSON o CSV
+func (bc *BulkController) ImportMovies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fallback, ok := bulk.FormatFromContentType(c.ContentType())
+		format, err := requestFormat(c, fallback, ok)
+		if err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		mode, err := bulk.ParseMode(c.Query("mode"))
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidMode, c.Query("mode")))
+			return
+		}
+
+		extendDeadlines(c, bulkTimeout)
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+		reader, err := bulk.NewMovieReader(body, format)
+		if tooLarge(err) {
+			apierror.Render(c, apierror.New(apierror.FileTooLarge, maxImportSize>>20))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidFile).With("cause", err.Error()))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, bulkTimeout)
+		defer cancel()
+
+		report, err := bc.importer.Import(ctx, reader, mode)
+		if tooLarge(err) {
+			apierror.Render(c, apierror.New(apierror.FileTooLarge, maxImportSize>>20).With("report", report))
+			return
+		}
+		if errors.Is(err, bulk.ErrMalformed) {
+			apierror.Render(c, apierror.New(apierror.InvalidFile).With("cause", err.Error()).With("report", report))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ImportFailed).With("report", report))
+			return
+		}
+
+		status := http.StatusOK
+		if !report.Committed {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, report)
+	}
+}
+
+// Exportar el catálogo o el resultado de una búsqueda
+func (bc *BulkController) ExportMovies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, err := requestFormat(c, bulk.FormatJSON, true)
+		if err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		query, err := movieQueryFromRequest(c)
+		if err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		extendDeadlines(c, bulkTimeout)
+		ctx, cancel := context.WithTimeout(c, bulkTimeout)
+		defer cancel()
+
+		filename := fmt.Sprintf("peliculas-%s.%s", time.Now().Format("20060102-150405"), format)
+		c.Header("Content-Type", format.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+
+		writer, err := bulk.NewMovieWriter(c.Writer, format)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		err = bc.movies.ForEach(ctx, query, func(movie models.Movie) error {
+			return writer.Write(movie)
+		})
+		if err != nil {
+			// La respuesta ya empezó: solo se puede cortar la descarga.
+			c.Abort()
+			return
+		}
+		writer.Close()
+	}
+}
+
+// extendDeadlines corre los plazos de lectura y escritura de la conexión
+// para este pedido. Si el servidor no los soporta (por ejemplo en pruebas con
+// httptest.ResponseRecorder) quedan los del servidor.
+func extendDeadlines(c *gin.Context, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	rc := http.NewResponseController(c.Writer)
+	if err := rc.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logging.FromContext(c).Warn("no se pudo extender el plazo de lectura", "error", err)
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		logging.FromContext(c).Warn("no se pudo extender el plazo de escritura", "error", err)
+	}
+}
+
+// tooLarge indica que la lectura se cortó por superar maxImportSize.
+func tooLarge(err error) bool {
+	var maxBytes *http.MaxBytesError
+	return errors.As(err, &maxBytes)
+}
+
+// requestFormat toma el formato del parámetro ?format= o, si no está, del valor por defecto.
+func requestFormat(c *gin.Context, fallback bulk.Format, hasFallback bool) (bulk.Format, error) {
+	if name := c.Query("format"); name != "" {
+		format, err := bulk.ParseFormat(name)
+		if err != nil {
+			return "", apierror.New(apierror.InvalidFormat, name)
+		}
+		return format, nil
+	}
+	if !hasFallback {
+		return "", apierror.New(apierror.FormatRequired)
+	}
+	return fallback, nil
+}
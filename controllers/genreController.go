@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GenreController struct {
+	genres *repositories.GenreRepository
+}
+
+func NewGenreController(genres *repositories.GenreRepository) *GenreController {
+	return &GenreController{genres: genres}
+}
+
+// Cuerpo para crear o renombrar un género
+type genreRequest struct {
+	GenreID   int               `json:"genre_id" validate:"min=0"`
+	GenreName string            `json:"genre_name" validate:"required,min=2,max=100"`
+	Names     map[string]string `json:"names" validate:"omitempty,dive,keys,oneof=es en,endkeys,min=2,max=100"`
+}
+
+// Un género con sus nombres por idioma y cuántos documentos lo usan
+type genreDetail struct {
+	GenreID   int                     `json:"genre_id"`
+	GenreName string                  `json:"genre_name"`
+	Names     map[string]string       `json:"names"`
+	Usage     repositories.GenreUsage `json:"usage"`
+}
+
+// Obtener géneros en el idioma del pedido
+func (gc *GenreController) GetGenres() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		genres, err := gc.genres.FindAll(ctx)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenresFetchFailed))
+			return
+		}
+
+		locale := utils.GetLocaleFromContext(c)
+		for i := range genres {
+			genres[i] = genres[i].Localized(locale)
+		}
+
+		c.JSON(http.StatusOK, genres)
+	}
+}
+
+// Obtener un género con la cantidad de películas y usuarios que lo usan
+func (gc *GenreController) GetGenre() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		genreID, ok := genreIDParam(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		genre, err := gc.genres.FindByID(ctx, genreID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.GenreNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenresFetchFailed))
+			return
+		}
+
+		usage, err := gc.genres.Usage(ctx, genreID)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenresFetchFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, genreDetail{GenreID: genre.GenreID, GenreName: genre.GenreName, Names: genre.Names, Usage: usage})
+	}
+}
+
+// Crear un género
+func (gc *GenreController) AddGenre() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req genreRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		req.GenreName = strings.TrimSpace(req.GenreName)
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		genre, err := gc.genres.Insert(ctx, models.Genre{GenreID: req.GenreID, GenreName: req.GenreName, Names: req.Names})
+		if errors.Is(err, repositories.ErrDuplicate) {
+			apierror.Render(c, apierror.New(apierror.GenreExists))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenreCreateFailed))
+			return
+		}
+
+		c.JSON(http.StatusCreated, genre)
+	}
+}
+
+// Renombrar un género (y sus nombres por idioma) y actualizar las películas y usuarios que lo usan
+func (gc *GenreController) UpdateGenre() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		genreID, ok := genreIDParam(c)
+		if !ok {
+			return
+		}
+
+		var req genreRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		req.GenreName = strings.TrimSpace(req.GenreName)
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+		if req.GenreID != 0 && req.GenreID != genreID {
+			apierror.Render(c, apierror.New(apierror.GenreIDImmutable))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		genre, err := gc.genres.Update(ctx, models.Genre{GenreID: genreID, GenreName: req.GenreName, Names: req.Names})
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.GenreNotFound))
+			return
+		}
+		if errors.Is(err, repositories.ErrDuplicate) {
+			apierror.Render(c, apierror.New(apierror.GenreNameTaken))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenreUpdateFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, genre)
+	}
+}
+
+// Borrar un género que no usa ninguna película ni usuario
+func (gc *GenreController) DeleteGenre() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		genreID, ok := genreIDParam(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		err := gc.genres.Delete(ctx, genreID)
+		var inUse *repositories.GenreInUseError
+		if errors.As(err, &inUse) {
+			apierror.Render(c, apierror.New(apierror.GenreInUse).With("usage", inUse.Usage))
+			return
+		}
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.GenreNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.GenreDeleteFailed))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// genreIDParam lee :genre_id y responde 400 si no es un número.
+func genreIDParam(c *gin.Context) (int, bool) {
+	genreID, err := strconv.Atoi(c.Param("genre_id"))
+	if err != nil || genreID < 1 {
+		apierror.Render(c, apierror.New(apierror.InvalidGenreID))
+		return 0, false
+	}
+	return genreID, true
+}
+
+// resolveGenres valida que los géneros existan y devuelve sus copias oficiales.
+// Si falla responde al cliente y devuelve false.
+func resolveGenres(ctx context.Context, c *gin.Context, genres *repositories.GenreRepository, requested []models.Genre) ([]models.Genre, bool) {
+	catalog, err := genres.Catalog(ctx)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.GenresFetchFailed))
+		return nil, false
+	}
+
+	resolved, err := catalog.Resolve(requested)
+	var unknown *models.UnknownGenresError
+	if errors.As(err, &unknown) {
+		apierror.Render(c, apierror.New(apierror.UnknownGenres).With("genre_ids", unknown.IDs))
+		return nil, false
+	}
+	return resolved, true
+}
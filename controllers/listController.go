@@ -0,0 +1,332 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Valor de :list_id que se refiere a la lista de pendientes
+const watchlistParam = "watchlist"
+
+type ListController struct {
+	lists    *repositories.ListRepository
+	movies   *repositories.MovieRepository
+	activity *repositories.ActivityRepository
+}
+
+func NewListController(lists *repositories.ListRepository, movies *repositories.MovieRepository, activity *repositories.ActivityRepository) *ListController {
+	return &ListController{lists: lists, movies: movies, activity: activity}
+}
+
+// Cuerpo para crear o cambiar una lista
+type listRequest struct {
+	Name       string                `json:"name" validate:"omitempty,min=1,max=100"`
+	Visibility models.ListVisibility `json:"visibility" validate:"omitempty,oneof=public private"`
+}
+
+// Cuerpo para agregar una película a una lista
+type listMovieRequest struct {
+	ImdbID string `json:"imdb_id" validate:"required"`
+}
+
+// Cuerpo para reordenar una lista: todas sus películas en el orden nuevo
+type reorderRequest struct {
+	ImdbIDs []string `json:"imdb_ids" validate:"required"`
+}
+
+// Listas del usuario, la de pendientes primero
+func (lc *ListController) MyLists() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := listUser(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		lists, err := lc.lists.FindByUser(ctx, userID)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ListsFetchFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, lists)
+	}
+}
+
+// Una lista del usuario
+func (lc *ListController) GetList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, list)
+	}
+}
+
+// Lista pública por su slug, sin iniciar sesión
+func (lc *ListController) PublicList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, err := lc.lists.FindPublic(ctx, c.Param("slug"))
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ListNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ListsFetchFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, list)
+	}
+}
+
+// Crear una lista (privada si no se indica la visibilidad)
+func (lc *ListController) CreateList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := listUser(c)
+		if !ok {
+			return
+		}
+
+		req, ok := bindListRequest(c)
+		if !ok {
+			return
+		}
+		if req.Name == "" {
+			apierror.Render(c, apierror.New(apierror.ListNameRequired))
+			return
+		}
+		if req.Visibility == "" {
+			req.Visibility = models.ListPrivate
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, err := lc.lists.Create(ctx, userID, req.Name, req.Visibility)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ListSaveFailed))
+			return
+		}
+
+		c.JSON(http.StatusCreated, list)
+	}
+}
+
+// Renombrar una lista o cambiar su visibilidad. La de pendientes no se renombra.
+func (lc *ListController) UpdateList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := bindListRequest(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+		if list.Kind == models.ListWatchlist && req.Name != "" {
+			apierror.Render(c, apierror.New(apierror.WatchlistImmutable))
+			return
+		}
+
+		updated, err := lc.lists.Update(ctx, list.UserID, list.ID, req.Name, req.Visibility)
+		lc.renderList(c, updated, err)
+	}
+}
+
+// Borrar una lista. La de pendientes no se borra.
+func (lc *ListController) DeleteList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+		if list.Kind == models.ListWatchlist {
+			apierror.Render(c, apierror.New(apierror.WatchlistImmutable))
+			return
+		}
+
+		if err := lc.lists.Delete(ctx, list.UserID, list.ID); err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ListSaveFailed))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Agregar una película al final de la lista
+func (lc *ListController) AddMovie() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req listMovieRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+
+		if _, err := lc.movies.FindByImdbID(ctx, req.ImdbID); errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		} else if err != nil {
+			apierror.Render(c, apierror.New(apierror.ListSaveFailed))
+			return
+		}
+
+		updated, err := lc.lists.AddMovie(ctx, list.UserID, list.ID, req.ImdbID)
+		if err == nil && list.Kind == models.ListWatchlist {
+			recordActivity(ctx, c, lc.activity, req.ImdbID, models.ActivityWatchlist)
+		}
+		lc.renderList(c, updated, err)
+	}
+}
+
+// Quitar una película de la lista
+func (lc *ListController) RemoveMovie() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+
+		updated, err := lc.lists.RemoveMovie(ctx, list.UserID, list.ID, c.Param("imdb_id"))
+		lc.renderList(c, updated, err)
+	}
+}
+
+// Cambiar el orden de las películas: {"imdb_ids": [...]} con todas las de la lista
+func (lc *ListController) ReorderList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req reorderRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		list, ok := lc.ownList(ctx, c)
+		if !ok {
+			return
+		}
+
+		updated, err := lc.lists.Reorder(ctx, list.UserID, list.ID, req.ImdbIDs)
+		lc.renderList(c, updated, err)
+	}
+}
+
+// ownList busca la lista de :list_id del usuario con sesión. "watchlist" se
+// refiere a la lista de pendientes. Si falla responde al cliente y devuelve false.
+func (lc *ListController) ownList(ctx context.Context, c *gin.Context) (*models.MovieList, bool) {
+	userID, ok := listUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	var list *models.MovieList
+	var err error
+	if param := c.Param("list_id"); param == watchlistParam {
+		list, err = lc.lists.Watchlist(ctx, userID)
+	} else {
+		id, parseErr := bson.ObjectIDFromHex(param)
+		if parseErr != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidListID))
+			return nil, false
+		}
+		list, err = lc.lists.FindByID(ctx, userID, id)
+	}
+	if errors.Is(err, repositories.ErrNotFound) {
+		apierror.Render(c, apierror.New(apierror.ListNotFound))
+		return nil, false
+	}
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.ListsFetchFailed))
+		return nil, false
+	}
+	return list, true
+}
+
+// renderList responde la lista actualizada o el error del repositorio.
+func (lc *ListController) renderList(c *gin.Context, list *models.MovieList, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		apierror.Render(c, apierror.New(apierror.ListNotFound))
+	case errors.Is(err, repositories.ErrListFull):
+		apierror.Render(c, apierror.New(apierror.ListFull, repositories.MaxListItems))
+	case errors.Is(err, repositories.ErrInvalidOrder):
+		apierror.Render(c, apierror.New(apierror.InvalidListOrder))
+	case err != nil:
+		apierror.Render(c, apierror.New(apierror.ListSaveFailed))
+	default:
+		c.JSON(http.StatusOK, list)
+	}
+}
+
+func listUser(c *gin.Context) (string, bool) {
+	userID, err := utils.GetUserIdFromContext(c)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.TokenMissing))
+		return "", false
+	}
+	return userID, true
+}
+
+func bindListRequest(c *gin.Context) (listRequest, bool) {
+	var req listRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Render(c, apierror.New(apierror.InvalidBody))
+		return req, false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err := models.Validate(req); err != nil {
+		apierror.Render(c, err)
+		return req, false
+	}
+	return req, true
+}
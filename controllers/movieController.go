@@ -2,227 +2,357 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
-	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/similar"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/suggest"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/trending"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
-	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-var validate = validator.New()
+type MovieController struct {
+	movies      *repositories.MovieRepository
+	genres      *repositories.GenreRepository
+	suggestions *suggest.Index
+	similar     *similar.Recommender
+	ratings     *repositories.RatingRepository
+	lists       *repositories.ListRepository
+	watch       *repositories.WatchRepository
+	activity    *repositories.ActivityRepository
+}
+
+func NewMovieController(movies *repositories.MovieRepository, genres *repositories.GenreRepository, suggestions *suggest.Index, recommender *similar.Recommender, ratings *repositories.RatingRepository, lists *repositories.ListRepository, watch *repositories.WatchRepository, activity *repositories.ActivityRepository) *MovieController {
+	return &MovieController{movies: movies, genres: genres, suggestions: suggestions, similar: recommender, ratings: ratings, lists: lists, watch: watch, activity: activity}
+}
 
-// Obtener todas las películas
-func GetMovies(client *mongo.Client) gin.HandlerFunc {
+// movieDetail es una película con la calificación del usuario que la pide.
+type movieDetail struct {
+	models.Movie
+	MyRating *int `json:"my_rating,omitempty"`
+}
+
+// Obtener todas las películas (?sort=user_score para ordenar por puntaje,
+// ?sort=popularity por popularidad del último día)
+func (mc *MovieController) GetMovies() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		sort := repositories.MovieSort(c.Query("sort"))
+		if sort != repositories.SortDefault && sort != repositories.SortUserScore && sort != repositories.SortPopularity {
+			apierror.Render(c, apierror.New(apierror.InvalidSort, sort))
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
-		movieCollection := database.OpenCollection("movies", client)
-
-		cursor, err := movieCollection.Find(ctx, bson.D{})
+		movies, err := mc.movies.FindAllSorted(ctx, sort)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al obtener las películas."})
+			apierror.Render(c, apierror.New(apierror.MoviesFetchFailed))
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var movies []models.Movie
-		if err := cursor.All(ctx, &movies); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al decodificar las películas."})
+		locale, catalog, ok := mc.localization(ctx, c)
+		if !ok {
+			return
+		}
+		memberships, ok := mc.memberships(ctx, c)
+		if !ok {
 			return
 		}
+		for i := range movies {
+			movies[i] = movies[i].Localized(locale, catalog)
+			movies[i].Membership = memberships[movies[i].ImdbID]
+		}
 
 		c.JSON(http.StatusOK, movies)
 	}
 }
 
 // Obtener una película por IMDB ID
-func GetMovie(client *mongo.Client) gin.HandlerFunc {
+func (mc *MovieController) GetMovie() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
 		movieID := c.Param("imdb_id")
 		if movieID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Se requiere el ID de la película"})
+			apierror.Render(c, apierror.New(apierror.MovieIDRequired))
 			return
 		}
 
-		movieCollection := database.OpenCollection("movies", client)
-		var movie models.Movie
-
-		err := movieCollection.FindOne(ctx, bson.D{{Key: "imdb_id", Value: movieID}}).Decode(&movie)
+		movie, err := mc.movies.FindByImdbID(ctx, movieID)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Película no encontrada"})
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
 			return
 		}
 
-		c.JSON(http.StatusOK, movie)
-	}
-}
-
-// Buscar películas por título o género
-func SearchMovies(client *mongo.Client) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		query := strings.TrimSpace(c.Query("title"))
-		if query == "" {
-			query = strings.TrimSpace(c.Query("query"))
+		locale, catalog, ok := mc.localization(ctx, c)
+		if !ok {
+			return
 		}
+		memberships, ok := mc.memberships(ctx, c)
+		if !ok {
+			return
+		}
+		detail := movieDetail{Movie: movie.Localized(locale, catalog)}
+		detail.Membership = memberships[movie.ImdbID]
+
+		// Con sesión iniciada se agrega la calificación propia
+		if userID, err := utils.GetUserIdFromContext(c); err == nil {
+			rating, err := mc.ratings.Find(ctx, userID, movieID)
+			if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+				apierror.Render(c, apierror.New(apierror.MoviesFetchFailed))
+				return
+			}
+			if rating != nil {
+				detail.MyRating = &rating.Value
+			}
+		}
+		recordActivity(ctx, c, mc.activity, movie.ImdbID, models.ActivityView)
 
-		genre := strings.TrimSpace(c.Query("genre"))
-
-		movieCollection := database.OpenCollection("movies", client)
+		c.JSON(http.StatusOK, detail)
+	}
+}
 
-		filter := bson.M{}
+// Límites de resultados de tendencias
+const (
+	defaultTrendingLimit = 20
+	maxTrendingLimit     = 100
+)
 
-		if query != "" {
-			filter["title"] = bson.M{
-				"$regex":   query,
-				"$options": "i",
-			}
+// Películas en tendencia por actividad reciente (?window=hourly|daily|weekly)
+func (mc *MovieController) TrendingMovies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		window, ok := trending.WindowByName(c.DefaultQuery("window", trending.Daily.Name))
+		if !ok {
+			apierror.Render(c, apierror.New(apierror.InvalidWindow, c.Query("window")))
+			return
 		}
 
-		if genre != "" {
-			filter["genre.genre_name"] = bson.M{
-				"$regex":   genre,
-				"$options": "i",
+		limit := defaultTrendingLimit
+		if value := c.Query("limit"); value != "" {
+			var err error
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > maxTrendingLimit {
+				apierror.Render(c, apierror.New(apierror.ParamOutOfRange, "limit", maxTrendingLimit))
+				return
 			}
 		}
 
-		findOptions := options.Find()
-		findOptions.SetCollation(&options.Collation{
-			Locale:   "es",
-			Strength: 1,
-		})
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
 
-		cursor, err := movieCollection.Find(ctx, filter, findOptions)
+		movies, err := mc.movies.FindTrending(ctx, window.Name, limit)
 		if err != nil {
-			c.JSON(http.StatusOK, []models.Movie{})
+			apierror.Render(c, apierror.New(apierror.MoviesFetchFailed))
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var movies []models.Movie
-		if err := cursor.All(ctx, &movies); err != nil {
-			c.JSON(http.StatusOK, []models.Movie{})
+		locale, catalog, ok := mc.localization(ctx, c)
+		if !ok {
 			return
 		}
+		memberships, ok := mc.memberships(ctx, c)
+		if !ok {
+			return
+		}
+		for i := range movies {
+			movies[i] = movies[i].Localized(locale, catalog)
+			movies[i].Membership = memberships[movies[i].ImdbID]
+		}
 
 		c.JSON(http.StatusOK, movies)
 	}
 }
 
-// Agregar una película
-func AddMovie(client *mongo.Client) gin.HandlerFunc {
+// Límites de resultados de películas parecidas
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// Películas parecidas por géneros, ranking y texto de la descripción y reseña.
+// Con sesión iniciada se omiten las que el usuario ya terminó de ver.
+func (mc *MovieController) SimilarMovies() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		movieID := c.Param("imdb_id")
+		if movieID == "" {
+			apierror.Render(c, apierror.New(apierror.MovieIDRequired))
+			return
+		}
+
+		limit := defaultSimilarLimit
+		if value := c.Query("limit"); value != "" {
+			var err error
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > maxSimilarLimit {
+				apierror.Render(c, apierror.New(apierror.ParamOutOfRange, "limit", maxSimilarLimit))
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c, 30*time.Second)
 		defer cancel()
 
-		var movie models.Movie
-		if err := c.ShouldBindJSON(&movie); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Datos de entrada inválidos"})
-			return
+		var watched map[string]bool
+		if userID, err := utils.GetUserIdFromContext(c); err == nil {
+			if watched, err = mc.watch.FinishedIDs(ctx, userID); err != nil {
+				apierror.Render(c, apierror.New(apierror.SimilarMoviesFailed))
+				return
+			}
 		}
 
-		if err := validate.Struct(movie); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Validación fallida", "detalles": err.Error()})
+		movies, err := mc.similar.Similar(ctx, movieID, limit, watched)
+		if errors.Is(err, similar.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
 			return
 		}
-
-		movieCollection := database.OpenCollection("movies", client)
-		result, err := movieCollection.InsertOne(ctx, movie)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo agregar la película"})
+			apierror.Render(c, apierror.New(apierror.SimilarMoviesFailed))
 			return
 		}
 
-		c.JSON(http.StatusCreated, result)
+		c.JSON(http.StatusOK, movies)
 	}
 }
 
-func AdminReview(client *mongo.Client) gin.HandlerFunc {
+// Buscar películas por texto o género, ordenadas por relevancia
+func (mc *MovieController) SearchMovies() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, err := utils.GetRoleFromContext(c)
+		query, err := movieQueryFromRequest(c)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No se encontró el rol en el contexto"})
+			apierror.Render(c, err)
 			return
 		}
 
-		if role != "ADMIN" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "El usuario debe ser ADMIN"})
+		page, pageSize, err := paginationParams(c)
+		if err != nil {
+			apierror.Render(c, err)
 			return
 		}
+		query.Page, query.PageSize = page, pageSize
 
-		movieID := c.Param("imdb_id")
-		if movieID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Se requiere el ID de la película"})
+		ctx, cancel := context.WithTimeout(c, 10*time.Second)
+		defer cancel()
+
+		result, err := mc.movies.Search(ctx, query)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.MovieSearchFailed))
 			return
 		}
 
-		var req struct {
-			AdminReview string `json:"admin_review"`
+		locale, catalog, ok := mc.localization(ctx, c)
+		if !ok {
+			return
 		}
-		if err := c.ShouldBind(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Cuerpo de solicitud inválido"})
+		memberships, ok := mc.memberships(ctx, c)
+		if !ok {
 			return
 		}
-
-		filter := bson.D{{Key: "imdb_id", Value: movieID}}
-		update := bson.M{
-			"$set": bson.M{
-				"admin_review": req.AdminReview, // solo guardamos la reseña
-			},
+		for i := range result.Movies {
+			result.Movies[i].Movie = result.Movies[i].Movie.Localized(locale, catalog)
+			result.Movies[i].Membership = memberships[result.Movies[i].ImdbID]
+		}
+		for i, facet := range result.Facets.Genres {
+			if genre, ok := catalog[facet.GenreID]; ok {
+				result.Facets.Genres[i].GenreName = genre.Localized(locale).GenreName
+			}
 		}
 
-		ctx, cancel := context.WithTimeout(c, 100*time.Second)
-		defer cancel()
+		c.JSON(http.StatusOK, result)
+	}
+}
 
-		movieCollection := database.OpenCollection("movies", client)
-		result, err := movieCollection.UpdateOne(ctx, filter, update)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar la película"})
-			return
-		}
+// Límites de resultados de autocompletado
+const (
+	defaultSuggestLimit = 8
+	maxSuggestLimit     = 20
+)
 
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Película no encontrada"})
-			return
+// Sugerencias de títulos mientras el usuario escribe
+func (mc *MovieController) SuggestMovies() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := defaultSuggestLimit
+		if value := c.Query("limit"); value != "" {
+			var err error
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > maxSuggestLimit {
+				apierror.Render(c, apierror.New(apierror.ParamOutOfRange, "limit", maxSuggestLimit))
+				return
+			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"admin_review": req.AdminReview})
+		c.JSON(http.StatusOK, mc.suggestions.Suggest(c.Query("q"), limit))
 	}
 }
 
-// Obtener géneros
-func GetGenres(client *mongo.Client) gin.HandlerFunc {
+// Agregar una película
+func (mc *MovieController) AddMovie() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
-		genreCollection := database.OpenCollection("genres", client)
-		cursor, err := genreCollection.Find(ctx, bson.D{})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al obtener géneros"})
+		var movie models.Movie
+		if err := c.ShouldBindJSON(&movie); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+
+		if err := models.Validate(movie); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		var ok bool
+		if movie.Genre, ok = resolveGenres(ctx, c, mc.genres, movie.Genre); !ok {
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var genres []models.Genre
-		if err := cursor.All(ctx, &genres); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		result, err := mc.movies.Insert(ctx, movie)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			apierror.Render(c, apierror.New(apierror.MovieExists))
 			return
 		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.MovieCreateFailed))
+			return
+		}
+
+		c.JSON(http.StatusCreated, result)
+	}
+}
+
+// localization devuelve el idioma del pedido y el catálogo de géneros para
+// traducir películas. Si no se pueden leer los géneros responde al cliente y
+// devuelve false.
+func (mc *MovieController) localization(ctx context.Context, c *gin.Context) (string, models.GenreCatalog, bool) {
+	catalog, err := mc.genres.Catalog(ctx)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.GenresFetchFailed))
+		return "", nil, false
+	}
+	return utils.GetLocaleFromContext(c), catalog, true
+}
+
+// memberships devuelve en qué listas del usuario con sesión está cada
+// película, por imdb_id. Sin sesión devuelve un mapa vacío. Si falla responde
+// al cliente y devuelve false.
+func (mc *MovieController) memberships(ctx context.Context, c *gin.Context) (map[string]*models.ListMembership, bool) {
+	userID, err := utils.GetUserIdFromContext(c)
+	if err != nil {
+		return nil, true
+	}
 
-		c.JSON(http.StatusOK, genres)
+	memberships, err := mc.lists.Memberships(ctx, userID)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.ListsFetchFailed))
+		return nil, false
 	}
+	return memberships, true
 }
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// Largo máximo del texto de búsqueda
+const maxSearchLength = 200
+
+// movieQueryFromRequest arma la búsqueda del catálogo a partir de los parámetros:
+//
+//	title / query                 texto libre
+//	genre, genres                 géneros por id o nombre, separados por coma
+//	genre_match                   any (por defecto) o all
+//	ranking_min, ranking_max      rango de ranking (1 = excelente, 5 = muy mala)
+//	year_from, year_to            rango de año de estreno
+//	has_review, has_trailer,
+//	has_watch_url                 true o false
+func movieQueryFromRequest(c *gin.Context) (repositories.MovieQuery, error) {
+	var query repositories.MovieQuery
+
+	query.Text = strings.TrimSpace(c.Query("title"))
+	if query.Text == "" {
+		query.Text = strings.TrimSpace(c.Query("query"))
+	}
+	if utf8.RuneCountInString(query.Text) > maxSearchLength {
+		return query, apierror.New(apierror.SearchTooLong, maxSearchLength)
+	}
+
+	for _, value := range append(c.QueryArray("genre"), c.QueryArray("genres")...) {
+		for _, genre := range strings.Split(value, ",") {
+			if genre = strings.TrimSpace(genre); genre != "" {
+				query.Genres = append(query.Genres, genre)
+			}
+		}
+	}
+
+	switch match := c.DefaultQuery("genre_match", string(repositories.MatchAny)); repositories.GenreMatch(match) {
+	case repositories.MatchAny, repositories.MatchAll:
+		query.GenreMatch = repositories.GenreMatch(match)
+	default:
+		return query, apierror.New(apierror.InvalidGenreMatch)
+	}
+
+	var err error
+	if query.RankingMin, err = intParam(c, "ranking_min"); err != nil {
+		return query, err
+	}
+	if query.RankingMax, err = intParam(c, "ranking_max"); err != nil {
+		return query, err
+	}
+	if query.YearFrom, err = intParam(c, "year_from"); err != nil {
+		return query, err
+	}
+	if query.YearTo, err = intParam(c, "year_to"); err != nil {
+		return query, err
+	}
+	if query.RankingMax > 0 && query.RankingMin > query.RankingMax {
+		return query, apierror.New(apierror.InvalidRange, "ranking_min", "ranking_max")
+	}
+	if query.YearTo > 0 && query.YearFrom > query.YearTo {
+		return query, apierror.New(apierror.InvalidRange, "year_from", "year_to")
+	}
+
+	if query.HasReview, err = boolParam(c, "has_review"); err != nil {
+		return query, err
+	}
+	if query.HasTrailer, err = boolParam(c, "has_trailer"); err != nil {
+		return query, err
+	}
+	if query.HasWatchURL, err = boolParam(c, "has_watch_url"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// intParam lee un parámetro entero positivo opcional. Cero significa sin valor.
+func intParam(c *gin.Context, name string) (int, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, apierror.New(apierror.InvalidNumber, name)
+	}
+	return number, nil
+}
+
+// boolParam lee un parámetro booleano opcional. nil significa sin filtro.
+func boolParam(c *gin.Context, name string) (*bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, apierror.New(apierror.InvalidBoolean, name)
+	}
+	return &flag, nil
+}
@@ -0,0 +1,428 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/bulk"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/openapi"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/similar"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/textdiff"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Etiquetas que agrupan las operaciones en la documentación
+const (
+	tagMovies          = "Películas"
+	tagGenres          = "Géneros"
+	tagUsers           = "Usuarios"
+	tagRatings         = "Calificaciones"
+	tagReviews         = "Reseñas"
+	tagLists           = "Listas"
+	tagHistory         = "Historial"
+	tagRecommendations = "Recomendaciones"
+	tagAdmin           = "Administración"
+)
+
+// APIDocs describe las rutas de los controladores para el documento OpenAPI,
+// relativas al prefijo de la versión (/api/v1). Cada ruta nueva de routes
+// necesita su entrada aquí: al armar el router se avisa de las rutas sin
+// documentar.
+func APIDocs() openapi.Spec {
+	return openapi.Spec{
+		Tags: []openapi.Tag{
+			{Name: tagMovies, Description: "Catálogo, búsqueda y tendencias"},
+			{Name: tagGenres},
+			{Name: tagUsers, Description: "Registro y sesión"},
+			{Name: tagRatings},
+			{Name: tagReviews},
+			{Name: tagLists, Description: "Lista de pendientes y listas propias"},
+			{Name: tagHistory, Description: "Reproducción e historial"},
+			{Name: tagRecommendations},
+			{Name: tagAdmin},
+		},
+		Enums: []openapi.Enum{
+			openapi.EnumOf(models.ListKind(""), models.ListWatchlist, models.ListCustom),
+			openapi.EnumOf(models.ListVisibility(""), models.ListPrivate, models.ListPublic),
+			openapi.EnumOf(models.ReviewStatus(""), models.ReviewPending, models.ReviewApproved, models.ReviewRejected, models.ReviewHidden),
+			openapi.EnumOf(models.PlaybackEvent(""), models.PlaybackStarted, models.PlaybackProgress, models.PlaybackFinished),
+			openapi.EnumOf(models.WatchStatus(""), models.WatchInProgress, models.WatchFinished),
+			openapi.EnumOf(models.RecommendationSource(""), models.SourceCollaborative, models.SourceGenres),
+			openapi.EnumOf(repositories.SearchMode(""), repositories.SearchAll, repositories.SearchText, repositories.SearchPartial),
+			openapi.EnumOf(bulk.Mode(""), bulk.ModeBestEffort, bulk.ModeAllOrNothing),
+		},
+		Names: []openapi.Name{
+			openapi.NameOf[apierror.Response]("Error"),
+			openapi.NameOf[apierror.Body]("ErrorBody"),
+			openapi.NameOf[textdiff.Op]("DiffOp"),
+		},
+		Routes: concat(movieDocs(), userDocs(), reviewDocs(), listDocs(), watchDocs(), adminDocs()),
+	}
+}
+
+func movieDocs() []openapi.Route {
+	imdbID := openapi.PathParam("imdb_id", "IMDb ID de la película", openapi.String())
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/movies", ID: "getMovies", Tag: tagMovies,
+			Summary:     "Listar todas las películas",
+			Description: "Con sesión iniciada cada película trae membership: en qué listas del usuario está.",
+			Auth:        openapi.Optional, Localized: true,
+			Params: []openapi.Parameter{
+				openapi.QueryParam("sort", "user_score: mejor calificadas primero; popularity: más populares del día", openapi.OneOf("", string(repositories.SortUserScore), string(repositories.SortPopularity))),
+			},
+			Response: []models.Movie{},
+		},
+		{
+			Method: http.MethodGet, Path: "/movies/trending", ID: "getTrendingMovies", Tag: tagMovies,
+			Summary: "Películas en tendencia por actividad reciente",
+			Auth:    openapi.Optional, Localized: true,
+			Params: []openapi.Parameter{
+				openapi.QueryParam("window", "Ventana de tiempo", openapi.OneOf("daily", "hourly", "daily", "weekly")),
+				openapi.QueryParam("limit", "", openapi.Int(1, maxTrendingLimit, defaultTrendingLimit)),
+			},
+			Response: []models.Movie{},
+		},
+		{
+			Method: http.MethodGet, Path: "/movies/:imdb_id", ID: "getMovie", Tag: tagMovies,
+			Summary:     "Obtener una película",
+			Description: "Con sesión iniciada incluye my_rating y membership.",
+			Auth:        openapi.Optional, Localized: true,
+			Params:   []openapi.Parameter{imdbID},
+			Response: movieDetail{},
+		},
+		{
+			Method: http.MethodGet, Path: "/movies/:imdb_id/similar", ID: "getSimilarMovies", Tag: tagMovies,
+			Summary:     "Películas parecidas",
+			Description: "Por géneros, ranking y texto. Con sesión iniciada se omiten las que el usuario ya terminó de ver.",
+			Auth:        openapi.Optional,
+			Params: []openapi.Parameter{
+				imdbID,
+				openapi.QueryParam("limit", "", openapi.Int(1, maxSimilarLimit, defaultSimilarLimit)),
+			},
+			Response: []similar.SimilarMovie{},
+		},
+		{
+			Method: http.MethodGet, Path: "/genres", ID: "getGenres", Tag: tagGenres,
+			Summary: "Listar los géneros", Localized: true,
+			Response: []models.Genre{},
+		},
+		{
+			Method: http.MethodGet, Path: "/search", ID: "searchMovies", Tag: tagMovies,
+			Summary:     "Buscar películas",
+			Description: "Búsqueda de texto con relevancia, filtros y facetas por género y ranking.",
+			Auth:        openapi.Optional, Localized: true,
+			Params:   append(searchParams(), pageParams()...),
+			Response: repositories.SearchResult{},
+		},
+		{
+			Method: http.MethodGet, Path: "/search/suggest", ID: "suggestMovies", Tag: tagMovies,
+			Summary: "Autocompletar títulos",
+			Params: []openapi.Parameter{
+				openapi.QueryParam("q", "Comienzo del título", openapi.String()),
+				openapi.QueryParam("limit", "", openapi.Int(1, maxSuggestLimit, defaultSuggestLimit)),
+			},
+			Response: []models.MovieSummary{},
+		},
+		{
+			Method: http.MethodPost, Path: "/movies", ID: "addMovie", Tag: tagMovies,
+			Summary: "Agregar una película", Auth: openapi.User,
+			Body: models.Movie{}, Status: http.StatusCreated, Response: mongo.InsertOneResult{},
+		},
+		{
+			Method: http.MethodPut, Path: "/movies/:imdb_id/admin-review", ID: "updateAdminReview", Tag: tagMovies,
+			Summary:     "Cambiar la reseña del administrador",
+			Description: "Cada cambio guarda una versión nueva en el historial.",
+			Auth:        openapi.Admin, Params: []openapi.Parameter{imdbID},
+			Body: adminReviewRequest{}, Response: adminReviewResponse{},
+		},
+		{
+			Method: http.MethodGet, Path: "/movies/:imdb_id/admin-review/history", ID: "getAdminReviewHistory", Tag: tagMovies,
+			Summary: "Historial de la reseña del administrador", Auth: openapi.Admin,
+			Params:   append([]openapi.Parameter{imdbID}, pageParams()...),
+			Response: repositories.AdminReviewHistory{},
+		},
+		{
+			Method: http.MethodPost, Path: "/movies/:imdb_id/admin-review/revert", ID: "revertAdminReview", Tag: tagMovies,
+			Summary:     "Volver a una versión anterior de la reseña",
+			Description: "La reversión se guarda como una versión nueva.",
+			Auth:        openapi.Admin, Params: []openapi.Parameter{imdbID},
+			Body: revertRequest{}, Response: models.AdminReviewVersion{},
+		},
+		{
+			Method: http.MethodPut, Path: "/movies/:imdb_id/rating", ID: "rateMovie", Tag: tagRatings,
+			Summary: "Calificar una película de 1 a 10", Auth: openapi.User,
+			Params: []openapi.Parameter{imdbID}, Body: ratingRequest{}, Response: models.Rating{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/movies/:imdb_id/rating", ID: "deleteRating", Tag: tagRatings,
+			Summary: "Borrar la calificación propia", Auth: openapi.User,
+			Params: []openapi.Parameter{imdbID}, Status: http.StatusNoContent,
+		},
+	}
+}
+
+func userDocs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/auth/register", ID: "registerUser", Tag: tagUsers,
+			Summary: "Registrar un usuario",
+			Body:    models.User{}, Status: http.StatusCreated, Response: mongo.InsertOneResult{},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/login", ID: "loginUser", Tag: tagUsers,
+			Summary:     "Iniciar sesión",
+			Description: "Deja los tokens en las cookies HttpOnly access_token y refresh_token.",
+			Body:        models.UserLogin{}, Response: models.UserResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/logout", ID: "logoutUser", Tag: tagUsers,
+			Summary: "Cerrar sesión", Description: "Invalida los tokens del usuario y borra las cookies.",
+			Body: logoutRequest{}, Response: messageResponse{},
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/refresh", ID: "refreshTokens", Tag: tagUsers,
+			Summary:     "Renovar los tokens",
+			Description: "Usa la cookie refresh_token y deja tokens nuevos en las cookies.",
+			Response:    messageResponse{},
+		},
+	}
+}
+
+func reviewDocs() []openapi.Route {
+	reviewID := openapi.PathParam("review_id", "Id de la reseña", openapi.String())
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/movies/:imdb_id/reviews", ID: "getMovieReviews", Tag: tagReviews,
+			Summary:  "Reseñas aprobadas de una película",
+			Params:   pageParams(),
+			Response: repositories.ReviewPage{},
+		},
+		{
+			Method: http.MethodGet, Path: "/users/:user_id/reviews", ID: "getUserReviews", Tag: tagReviews,
+			Summary:  "Reseñas aprobadas de un usuario",
+			Params:   pageParams(),
+			Response: repositories.ReviewPage{},
+		},
+		{
+			Method: http.MethodPost, Path: "/movies/:imdb_id/reviews", ID: "createReview", Tag: tagReviews,
+			Summary:     "Escribir la reseña de una película",
+			Description: "Si la revisión automática encuentra algo sospechoso queda pendiente de moderación.",
+			Auth:        openapi.User, Body: reviewRequest{}, Status: http.StatusCreated, Response: models.Review{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/reviews/:review_id", ID: "updateReview", Tag: tagReviews,
+			Summary: "Editar la reseña propia", Auth: openapi.User,
+			Params: []openapi.Parameter{reviewID}, Body: reviewRequest{}, Response: models.Review{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/reviews/:review_id", ID: "deleteReview", Tag: tagReviews,
+			Summary: "Borrar una reseña (el autor o un administrador)", Auth: openapi.User,
+			Params: []openapi.Parameter{reviewID}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/me/reviews", ID: "getMyReviews", Tag: tagReviews,
+			Summary: "Reseñas propias en cualquier estado", Auth: openapi.User,
+			Params: pageParams(), Response: repositories.ReviewPage{},
+		},
+	}
+}
+
+func listDocs() []openapi.Route {
+	listID := openapi.PathParam("list_id", `Id de la lista, o "watchlist" para la de pendientes`, openapi.String())
+	return []openapi.Route{
+		{
+			Method: http.MethodGet, Path: "/lists/:slug", ID: "getPublicList", Tag: tagLists,
+			Summary: "Lista pública por su slug", Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodGet, Path: "/me/lists", ID: "getMyLists", Tag: tagLists,
+			Summary: "Listas propias, la de pendientes primero", Auth: openapi.User,
+			Response: []models.MovieList{},
+		},
+		{
+			Method: http.MethodPost, Path: "/me/lists", ID: "createList", Tag: tagLists,
+			Summary: "Crear una lista (privada si no se indica)", Auth: openapi.User,
+			Body: listRequest{}, Status: http.StatusCreated, Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodGet, Path: "/me/lists/:list_id", ID: "getList", Tag: tagLists,
+			Summary: "Obtener una lista propia", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/me/lists/:list_id", ID: "updateList", Tag: tagLists,
+			Summary: "Renombrar una lista o cambiar su visibilidad", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Body: listRequest{}, Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/me/lists/:list_id", ID: "deleteList", Tag: tagLists,
+			Summary: "Borrar una lista (no la de pendientes)", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodPut, Path: "/me/lists/:list_id/order", ID: "reorderList", Tag: tagLists,
+			Summary: "Cambiar el orden de las películas", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Body: reorderRequest{}, Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodPost, Path: "/me/lists/:list_id/movies", ID: "addListMovie", Tag: tagLists,
+			Summary: "Agregar una película al final de la lista", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Body: listMovieRequest{}, Response: models.MovieList{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/me/lists/:list_id/movies/:imdb_id", ID: "removeListMovie", Tag: tagLists,
+			Summary: "Quitar una película de la lista", Auth: openapi.User,
+			Params: []openapi.Parameter{listID}, Response: models.MovieList{},
+		},
+	}
+}
+
+func watchDocs() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/me/playback", ID: "recordPlayback", Tag: tagHistory,
+			Summary:     "Registrar un evento del reproductor",
+			Description: "Al pasar el 95% de la duración la película cuenta como terminada.",
+			Auth:        openapi.User, Body: playbackRequest{}, Response: models.WatchEntry{},
+		},
+		{
+			Method: http.MethodGet, Path: "/me/history", ID: "getHistory", Tag: tagHistory,
+			Summary: "Historial de reproducción, lo último visto primero", Auth: openapi.User,
+			Params: pageParams(), Response: repositories.WatchHistoryPage{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/me/history/:imdb_id", ID: "deleteHistoryEntry", Tag: tagHistory,
+			Summary: "Quitar una película del historial", Auth: openapi.User,
+			Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/me/continue-watching", ID: "getContinueWatching", Tag: tagHistory,
+			Summary: "Películas empezadas y sin terminar", Auth: openapi.User,
+			Params: []openapi.Parameter{
+				openapi.QueryParam("limit", "", openapi.Int(1, maxContinueLimit, defaultContinueLimit)),
+			},
+			Response: []models.WatchEntry{},
+		},
+		{
+			Method: http.MethodGet, Path: "/me/recommendations", ID: "getRecommendations", Tag: tagRecommendations,
+			Summary:     "Recomendaciones personales",
+			Description: "Según lo que el usuario calificó y vio, completadas con sus géneros favoritos.",
+			Auth:        openapi.User, Localized: true,
+			Params: []openapi.Parameter{
+				openapi.QueryParam("limit", "", openapi.Int(1, maxRecommendationLimit, defaultRecommendationLimit)),
+			},
+			Response: []models.Recommendation{},
+		},
+	}
+}
+
+func adminDocs() []openapi.Route {
+	genreID := openapi.PathParam("genre_id", "", openapi.Int(1, 0, 0))
+	formats := []string{string(bulk.FormatJSON), string(bulk.FormatNDJSON), string(bulk.FormatCSV)}
+	files := map[string]*openapi.Schema{
+		"application/x-ndjson": openapi.Binary(),
+		"text/csv":             openapi.Binary(),
+	}
+	return []openapi.Route{
+		{
+			Method: http.MethodPost, Path: "/admin/movies/import", ID: "importMovies", Tag: tagAdmin,
+			Summary:     "Importar películas por imdb_id",
+			Description: "El formato sale de ?format= o del Content-Type. Responde 422 si en all-or-nothing alguna fila falla y no se guardó nada.",
+			Auth:        openapi.Admin,
+			Params: []openapi.Parameter{
+				openapi.QueryParam("format", "", openapi.OneOf("", formats...)),
+				openapi.QueryParam("mode", "", openapi.OneOf(string(bulk.ModeBestEffort), string(bulk.ModeBestEffort), string(bulk.ModeAllOrNothing))),
+			},
+			Body: []models.Movie{}, BodyContent: files,
+			Response:      bulk.ImportReport{},
+			MoreResponses: map[int]any{http.StatusUnprocessableEntity: bulk.ImportReport{}},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/movies/export", ID: "exportMovies", Tag: tagAdmin,
+			Summary:     "Exportar el catálogo o una búsqueda",
+			Description: "Acepta los mismos filtros que /search.",
+			Auth:        openapi.Admin,
+			Params: append([]openapi.Parameter{
+				openapi.QueryParam("format", "", openapi.OneOf(string(bulk.FormatJSON), formats...)),
+			}, searchParams()...),
+			ResponseContent: map[string]*openapi.Schema{
+				"application/json":     openapi.Binary(),
+				"application/x-ndjson": openapi.Binary(),
+				"text/csv":             openapi.Binary(),
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/genres", ID: "createGenre", Tag: tagAdmin,
+			Summary: "Crear un género", Auth: openapi.Admin,
+			Body: genreRequest{}, Status: http.StatusCreated, Response: models.Genre{},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/genres/:genre_id", ID: "getGenre", Tag: tagAdmin,
+			Summary: "Obtener un género con su uso", Auth: openapi.Admin,
+			Params: []openapi.Parameter{genreID}, Response: genreDetail{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/admin/genres/:genre_id", ID: "updateGenre", Tag: tagAdmin,
+			Summary:     "Renombrar un género",
+			Description: "También actualiza las películas y usuarios que lo usan.",
+			Auth:        openapi.Admin, Params: []openapi.Parameter{genreID},
+			Body: genreRequest{}, Response: models.Genre{},
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/genres/:genre_id", ID: "deleteGenre", Tag: tagAdmin,
+			Summary: "Borrar un género sin uso", Auth: openapi.Admin,
+			Params: []openapi.Parameter{genreID}, Status: http.StatusNoContent,
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/reviews", ID: "getModerationQueue", Tag: tagAdmin,
+			Summary: "Cola de moderación de reseñas", Auth: openapi.Admin,
+			Params: append([]openapi.Parameter{
+				openapi.QueryParam("status", "", openapi.OneOf(string(models.ReviewPending),
+					string(models.ReviewPending), string(models.ReviewApproved), string(models.ReviewRejected), string(models.ReviewHidden))),
+			}, pageParams()...),
+			Response: repositories.ReviewPage{},
+		},
+		{
+			Method: http.MethodPatch, Path: "/admin/reviews/:review_id", ID: "moderateReview", Tag: tagAdmin,
+			Summary: "Aprobar, rechazar u ocultar una reseña", Auth: openapi.Admin,
+			Body: moderationRequest{}, Response: models.Review{},
+		},
+	}
+}
+
+// pageParams son los parámetros de paginationParams.
+func pageParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("page", "", openapi.Int(1, maxPage, 1)),
+		openapi.QueryParam("page_size", "", openapi.Int(1, maxPageSize, defaultPageSize)),
+	}
+}
+
+// searchParams son los filtros de movieQueryFromRequest.
+func searchParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		openapi.QueryParam("query", "Texto libre", openapi.MaxLength(openapi.String(), maxSearchLength)),
+		openapi.QueryParam("title", "Alias de query", openapi.String()),
+		openapi.QueryParam("genre", "Géneros por id o nombre, separados por coma", openapi.String()),
+		openapi.QueryParam("genres", "Alias de genre", openapi.String()),
+		openapi.QueryParam("genre_match", "", openapi.OneOf(string(repositories.MatchAny), string(repositories.MatchAny), string(repositories.MatchAll))),
+		openapi.QueryParam("ranking_min", "1 = excelente, 5 = muy mala", openapi.Int(0, 0, 0)),
+		openapi.QueryParam("ranking_max", "", openapi.Int(0, 0, 0)),
+		openapi.QueryParam("year_from", "", openapi.Int(0, 0, 0)),
+		openapi.QueryParam("year_to", "", openapi.Int(0, 0, 0)),
+		openapi.QueryParam("has_review", "", openapi.Bool()),
+		openapi.QueryParam("has_trailer", "", openapi.Bool()),
+		openapi.QueryParam("has_watch_url", "", openapi.Bool()),
+	}
+}
+
+func concat(groups ...[]openapi.Route) []openapi.Route {
+	var routes []openapi.Route
+	for _, group := range groups {
+		routes = append(routes, group...)
+	}
+	return routes
+}
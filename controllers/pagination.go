@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/gin-gonic/gin"
+)
+
+// Tamaños de página para los listados paginados
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// Página más alta: (page-1)*page_size se usa como $skip y no puede desbordar
+	maxPage = math.MaxInt32 / maxPageSize
+)
+
+// paginationParams lee ?page= y ?page_size= con sus valores por defecto.
+func paginationParams(c *gin.Context) (page, pageSize int, err error) {
+	page, pageSize = 1, defaultPageSize
+
+	if value := c.Query("page"); value != "" {
+		page, err = strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return 0, 0, apierror.New(apierror.InvalidPage)
+		}
+		if page > maxPage {
+			return 0, 0, apierror.New(apierror.ParamOutOfRange, "page", maxPage)
+		}
+	}
+
+	if value := c.Query("page_size"); value != "" {
+		pageSize, err = strconv.Atoi(value)
+		if err != nil || pageSize < 1 || pageSize > maxPageSize {
+			return 0, 0, apierror.New(apierror.ParamOutOfRange, "page_size", maxPageSize)
+		}
+	}
+
+	return page, pageSize, nil
+}
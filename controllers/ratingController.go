@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type RatingController struct {
+	ratings  *repositories.RatingRepository
+	activity *repositories.ActivityRepository
+}
+
+func NewRatingController(ratings *repositories.RatingRepository, activity *repositories.ActivityRepository) *RatingController {
+	return &RatingController{ratings: ratings, activity: activity}
+}
+
+// Cuerpo para calificar una película
+type ratingRequest struct {
+	Rating int `json:"rating" validate:"required,min=1,max=10"`
+}
+
+// Calificar una película de 1 a 10, o cambiar la calificación anterior
+func (rc *RatingController) RateMovie() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		var req ratingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		rating, err := rc.ratings.Rate(ctx, userID, c.Param("imdb_id"), req.Rating)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RatingFailed))
+			return
+		}
+		recordActivity(ctx, c, rc.activity, rating.ImdbID, models.ActivityRating)
+
+		c.JSON(http.StatusOK, rating)
+	}
+}
+
+// Borrar la calificación del usuario a una película
+func (rc *RatingController) DeleteRating() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		err = rc.ratings.Delete(ctx, userID, c.Param("imdb_id"))
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.RatingNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RatingFailed))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/recommend"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Límites de recomendaciones personales
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 50
+)
+
+type RecommendationController struct {
+	recommendations *repositories.RecommendationRepository
+	movies          *repositories.MovieRepository
+	users           *repositories.UserRepository
+}
+
+func NewRecommendationController(recommendations *repositories.RecommendationRepository, movies *repositories.MovieRepository, users *repositories.UserRepository) *RecommendationController {
+	return &RecommendationController{recommendations: recommendations, movies: movies, users: users}
+}
+
+// Recomendaciones para el usuario según lo que calificó y vio, y sus
+// géneros favoritos
+func (rc *RecommendationController) MyRecommendations() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		limit := defaultRecommendationLimit
+		if value := c.Query("limit"); value != "" {
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > maxRecommendationLimit {
+				apierror.Render(c, apierror.New(apierror.ParamOutOfRange, "limit", maxRecommendationLimit))
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		user, err := rc.users.FindByUserID(ctx, userID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.UserNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RecommendationsFailed))
+			return
+		}
+
+		interactions, err := rc.recommendations.Interactions(ctx, userID)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RecommendationsFailed))
+			return
+		}
+		seen := make([]string, len(interactions))
+		for i, interaction := range interactions {
+			seen[i] = interaction.ImdbID
+		}
+		neighbours, err := rc.recommendations.Neighbours(ctx, seen)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RecommendationsFailed))
+			return
+		}
+		// Solo pueden puntuar las vecinas y las películas de los géneros favoritos
+		var candidates []string
+		for _, list := range neighbours {
+			for _, neighbour := range list {
+				candidates = append(candidates, neighbour.ImdbID)
+			}
+		}
+		genreIDs := make([]int, len(user.FavouriteGenres))
+		for i, genre := range user.FavouriteGenres {
+			genreIDs[i] = genre.GenreID
+		}
+		catalogue, err := rc.movies.FindCandidates(ctx, candidates, genreIDs)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RecommendationsFailed))
+			return
+		}
+
+		scored := recommend.Recommend(recommend.Input{
+			Interactions:    interactions,
+			Neighbours:      neighbours,
+			Catalogue:       catalogue,
+			FavouriteGenres: user.FavouriteGenres,
+			Limit:           limit,
+		})
+
+		byID := make(map[string]models.Movie, len(catalogue))
+		for _, movie := range catalogue {
+			byID[movie.ImdbID] = movie
+		}
+		locale := utils.GetLocaleFromContext(c)
+		recommendations := make([]models.Recommendation, len(scored))
+		for i, result := range scored {
+			movie := byID[result.ImdbID].Localized(locale, nil)
+			recommendations[i] = models.Recommendation{
+				MovieSummary: models.MovieSummary{ImdbID: movie.ImdbID, Title: movie.Title, PosterPath: movie.PosterPath},
+				Score:        result.Score,
+				Source:       result.Source,
+			}
+		}
+
+		c.JSON(http.StatusOK, recommendations)
+	}
+}
@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/moderation"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ReviewController struct {
+	reviews *repositories.ReviewRepository
+	movies  *repositories.MovieRepository
+	users   *repositories.UserRepository
+}
+
+func NewReviewController(reviews *repositories.ReviewRepository, movies *repositories.MovieRepository, users *repositories.UserRepository) *ReviewController {
+	return &ReviewController{reviews: reviews, movies: movies, users: users}
+}
+
+// Cuerpo para escribir o editar una reseña
+type reviewRequest struct {
+	Text string `json:"text" validate:"required,min=10,max=5000"`
+}
+
+// Reseñas aprobadas de una película
+func (rc *ReviewController) MovieReviews() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rc.listReviews(c, repositories.ReviewQuery{
+			ImdbID:   c.Param("imdb_id"),
+			Statuses: []models.ReviewStatus{models.ReviewApproved},
+		})
+	}
+}
+
+// Reseñas aprobadas de un usuario
+func (rc *ReviewController) UserReviews() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rc.listReviews(c, repositories.ReviewQuery{
+			UserID:   c.Param("user_id"),
+			Statuses: []models.ReviewStatus{models.ReviewApproved},
+		})
+	}
+}
+
+// Reseñas del usuario con sesión, en cualquier estado
+func (rc *ReviewController) MyReviews() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+		rc.listReviews(c, repositories.ReviewQuery{UserID: userID})
+	}
+}
+
+// Cola de moderación (?status=, por defecto pending)
+func (rc *ReviewController) ModerationQueue() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := models.ReviewStatus(c.DefaultQuery("status", string(models.ReviewPending)))
+		if !validReviewStatus(status) {
+			apierror.Render(c, apierror.New(apierror.InvalidReviewStatus, status))
+			return
+		}
+		rc.listReviews(c, repositories.ReviewQuery{Statuses: []models.ReviewStatus{status}})
+	}
+}
+
+// Escribir la reseña de una película. Si la revisión automática encuentra
+// algo sospechoso queda pendiente de moderación; si no, se publica.
+func (rc *ReviewController) CreateReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		req, ok := bindReviewRequest(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		imdbID := c.Param("imdb_id")
+		if _, err := rc.movies.FindByImdbID(ctx, imdbID); errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		} else if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewSaveFailed))
+			return
+		}
+
+		user, err := rc.users.FindByUserID(ctx, userID)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.UserNotFound))
+			return
+		}
+
+		flags := moderation.Check(req.Text)
+		review, err := rc.reviews.Insert(ctx, models.Review{
+			UserID:   userID,
+			UserName: displayName(user),
+			ImdbID:   imdbID,
+			Text:     req.Text,
+			Status:   reviewStatus(flags, ""),
+			Flags:    flags,
+		})
+		if errors.Is(err, repositories.ErrDuplicate) {
+			apierror.Render(c, apierror.New(apierror.ReviewExists))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewSaveFailed))
+			return
+		}
+
+		c.JSON(http.StatusCreated, review)
+	}
+}
+
+// Editar la reseña propia. El texto nuevo vuelve a pasar la revisión automática.
+func (rc *ReviewController) UpdateReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := bindReviewRequest(c)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		review, ok := rc.authorizedReview(ctx, c, false)
+		if !ok {
+			return
+		}
+
+		flags := moderation.Check(req.Text)
+		updated, err := rc.reviews.UpdateText(ctx, review.ID, req.Text, reviewStatus(flags, review.Status), flags)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ReviewNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewSaveFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, updated)
+	}
+}
+
+// Borrar una reseña. Puede hacerlo el autor o un administrador.
+func (rc *ReviewController) DeleteReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		review, ok := rc.authorizedReview(ctx, c, true)
+		if !ok {
+			return
+		}
+
+		err := rc.reviews.Delete(ctx, review.ID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ReviewNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewSaveFailed))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Cuerpo para moderar una reseña
+type moderationRequest struct {
+	Status models.ReviewStatus `json:"status" validate:"required,oneof=approved rejected hidden"`
+	Note   string              `json:"note" validate:"max=500"`
+}
+
+// Aprobar, rechazar u ocultar una reseña
+func (rc *ReviewController) ModerateReview() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		adminID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		reviewID, ok := reviewIDParam(c)
+		if !ok {
+			return
+		}
+
+		var req moderationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		review, err := rc.reviews.Moderate(ctx, reviewID, req.Status, strings.TrimSpace(req.Note), adminID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.ReviewNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.ReviewSaveFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, review)
+	}
+}
+
+func (rc *ReviewController) listReviews(c *gin.Context, query repositories.ReviewQuery) {
+	page, pageSize, err := paginationParams(c)
+	if err != nil {
+		apierror.Render(c, err)
+		return
+	}
+	query.Page, query.PageSize = page, pageSize
+
+	ctx, cancel := context.WithTimeout(c, 100*time.Second)
+	defer cancel()
+
+	result, err := rc.reviews.Find(ctx, query)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.ReviewsFetchFailed))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// authorizedReview busca la reseña de :review_id y verifica que el usuario
+// sea su autor (o administrador, si allowAdmin). Si falla responde al cliente
+// y devuelve false.
+func (rc *ReviewController) authorizedReview(ctx context.Context, c *gin.Context, allowAdmin bool) (*models.Review, bool) {
+	userID, err := utils.GetUserIdFromContext(c)
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.TokenMissing))
+		return nil, false
+	}
+
+	reviewID, ok := reviewIDParam(c)
+	if !ok {
+		return nil, false
+	}
+
+	review, err := rc.reviews.FindByID(ctx, reviewID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		apierror.Render(c, apierror.New(apierror.ReviewNotFound))
+		return nil, false
+	}
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.ReviewsFetchFailed))
+		return nil, false
+	}
+
+	role, _ := utils.GetRoleFromContext(c)
+	if review.UserID != userID && !(allowAdmin && role == "ADMIN") {
+		apierror.Render(c, apierror.New(apierror.ReviewForbidden))
+		return nil, false
+	}
+	return review, true
+}
+
+func bindReviewRequest(c *gin.Context) (reviewRequest, bool) {
+	var req reviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.Render(c, apierror.New(apierror.InvalidBody))
+		return req, false
+	}
+	req.Text = strings.TrimSpace(req.Text)
+	if err := models.Validate(req); err != nil {
+		apierror.Render(c, err)
+		return req, false
+	}
+	return req, true
+}
+
+// reviewIDParam lee :review_id y responde 400 si no es un ObjectID.
+func reviewIDParam(c *gin.Context) (bson.ObjectID, bool) {
+	id, err := bson.ObjectIDFromHex(c.Param("review_id"))
+	if err != nil {
+		apierror.Render(c, apierror.New(apierror.InvalidReviewID))
+		return id, false
+	}
+	return id, true
+}
+
+// reviewStatus decide el estado de una reseña nueva o editada: queda
+// pendiente si la revisión automática la marcó o si un administrador la había
+// rechazado u ocultado; si no, se publica.
+func reviewStatus(flags []string, previous models.ReviewStatus) models.ReviewStatus {
+	if len(flags) > 0 || previous == models.ReviewRejected || previous == models.ReviewHidden {
+		return models.ReviewPending
+	}
+	return models.ReviewApproved
+}
+
+func validReviewStatus(status models.ReviewStatus) bool {
+	switch status {
+	case models.ReviewPending, models.ReviewApproved, models.ReviewRejected, models.ReviewHidden:
+		return true
+	}
+	return false
+}
+
+// displayName es el nombre público del autor: nombre e inicial del apellido.
+func displayName(user *models.User) string {
+	initial, _ := utf8.DecodeRuneInString(user.LastName)
+	if initial == utf8.RuneError {
+		return user.FirstName
+	}
+	return user.FirstName + " " + string(initial) + "."
+}
@@ -2,15 +2,18 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/metrics"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/tracing"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -22,37 +25,43 @@ func HashPassword(password string) (string, error) {
 	return string(hash), nil
 }
 
-func RegisterUser(client *mongo.Client) gin.HandlerFunc {
+type UserController struct {
+	users   *repositories.UserRepository
+	genres  *repositories.GenreRepository
+	tokens  *utils.TokenService
+	metrics *metrics.Metrics
+}
+
+func NewUserController(users *repositories.UserRepository, genres *repositories.GenreRepository, tokens *utils.TokenService, m *metrics.Metrics) *UserController {
+	return &UserController{users: users, genres: genres, tokens: tokens, metrics: m}
+}
+
+func (uc *UserController) RegisterUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var user models.User
 		if err := c.ShouldBindJSON(&user); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Datos de entrada inválidos"})
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
 			return
 		}
 
-		if err := validate.Struct(user); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Validación fallida", "detalles": err.Error()})
+		if err := models.Validate(user); err != nil {
+			apierror.Render(c, err)
 			return
 		}
 
+		_, span := tracing.Start(c, "bcrypt.hash")
 		hashedPassword, err := HashPassword(user.Password)
+		span.End()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo encriptar la contraseña"})
+			apierror.Render(c, apierror.New(apierror.PasswordHashFailed))
 			return
 		}
 
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
-		userCollection := database.OpenCollection("users", client)
-
-		count, err := userCollection.CountDocuments(ctx, bson.D{{Key: "email", Value: user.Email}})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al verificar usuario existente"})
-			return
-		}
-		if count > 0 {
-			c.JSON(http.StatusConflict, gin.H{"error": "El usuario ya existe"})
+		var ok bool
+		if user.FavouriteGenres, ok = resolveGenres(ctx, c, uc.genres, user.FavouriteGenres); !ok {
 			return
 		}
 
@@ -61,9 +70,13 @@ func RegisterUser(client *mongo.Client) gin.HandlerFunc {
 		user.UpdatedAt = time.Now()
 		user.Password = hashedPassword
 
-		result, err := userCollection.InsertOne(ctx, user)
+		result, err := uc.users.Insert(ctx, user)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			apierror.Render(c, apierror.New(apierror.UserExists))
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al crear el usuario"})
+			apierror.Render(c, apierror.New(apierror.UserCreateFailed))
 			return
 		}
 
@@ -71,41 +84,42 @@ func RegisterUser(client *mongo.Client) gin.HandlerFunc {
 	}
 }
 
-func LoginUser(client *mongo.Client) gin.HandlerFunc {
+func (uc *UserController) LoginUser() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var userLogin models.UserLogin
 		if err := c.ShouldBindJSON(&userLogin); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Datos de entrada inválidos"})
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
 			return
 		}
 
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
-		userCollection := database.OpenCollection("users", client)
-
-		var foundUser models.User
-		err := userCollection.FindOne(ctx, bson.D{{Key: "email", Value: userLogin.Email}}).Decode(&foundUser)
+		foundUser, err := uc.users.FindByEmail(ctx, userLogin.Email)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Correo o contraseña inválidos"})
+			uc.metrics.Login(false)
+			apierror.Render(c, apierror.New(apierror.InvalidCredentials))
 			return
 		}
 
+		_, span := tracing.Start(ctx, "bcrypt.compare")
 		err = bcrypt.CompareHashAndPassword([]byte(foundUser.Password), []byte(userLogin.Password))
+		span.End()
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Correo o contraseña inválidos"})
+			uc.metrics.Login(false)
+			apierror.Render(c, apierror.New(apierror.InvalidCredentials))
 			return
 		}
 
-		token, refreshToken, err := utils.GenerateAllTokens(foundUser.Email, foundUser.FirstName, foundUser.LastName, foundUser.Role, foundUser.UserID)
+		token, refreshToken, err := uc.tokens.GenerateAllTokens(ctx, foundUser.Email, foundUser.FirstName, foundUser.LastName, foundUser.Role, foundUser.UserID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al generar tokens"})
+			apierror.Render(c, apierror.New(apierror.TokenGenerationFailed))
 			return
 		}
 
-		err = utils.UpdateAllTokens(foundUser.UserID, token, refreshToken, client)
+		err = uc.users.UpdateTokens(ctx, foundUser.UserID, token, refreshToken)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar tokens"})
+			apierror.Render(c, apierror.New(apierror.TokenUpdateFailed))
 			return
 		}
 
@@ -128,6 +142,7 @@ func LoginUser(client *mongo.Client) gin.HandlerFunc {
 			HttpOnly: true,
 			SameSite: http.SameSiteLaxMode,
 		})
+		uc.metrics.Login(true)
 
 		c.JSON(http.StatusOK, models.UserResponse{
 			UserID:          foundUser.UserID,
@@ -140,19 +155,30 @@ func LoginUser(client *mongo.Client) gin.HandlerFunc {
 	}
 }
 
-func LogoutHandler(client *mongo.Client) gin.HandlerFunc {
+// Cuerpo para cerrar sesión
+type logoutRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// Respuesta que solo confirma la operación
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+func (uc *UserController) LogoutHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var logoutRequest struct {
-			UserId string `json:"user_id"`
-		}
-		if err := c.ShouldBindJSON(&logoutRequest); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Datos inválidos"})
+		var req logoutRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
 			return
 		}
 
-		err := utils.UpdateAllTokens(logoutRequest.UserId, "", "", client)
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		err := uc.users.UpdateTokens(ctx, req.UserId, "", "")
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al cerrar sesión"})
+			apierror.Render(c, apierror.New(apierror.LogoutFailed))
 			return
 		}
 
@@ -175,45 +201,47 @@ func LogoutHandler(client *mongo.Client) gin.HandlerFunc {
 			SameSite: http.SameSiteLaxMode,
 		})
 
-		c.JSON(http.StatusOK, gin.H{"message": "Sesión cerrada correctamente"})
+		c.JSON(http.StatusOK, messageResponse{Message: "Sesión cerrada correctamente"})
 	}
 }
 
-func RefreshTokenHandler(client *mongo.Client) gin.HandlerFunc {
+func (uc *UserController) RefreshTokenHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx, cancel := context.WithTimeout(c, 100*time.Second)
 		defer cancel()
 
 		refreshToken, err := c.Cookie("refresh_token")
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No se pudo obtener el token de actualización"})
+			uc.metrics.Refresh(false)
+			apierror.Render(c, apierror.New(apierror.RefreshTokenMissing))
 			return
 		}
 
-		claim, err := utils.ValidateRefreshToken(refreshToken)
+		claim, err := uc.tokens.ValidateRefreshToken(ctx, refreshToken)
 		if err != nil || claim == nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token de actualización inválido o expirado"})
+			uc.metrics.Refresh(false)
+			apierror.Render(c, apierror.New(apierror.RefreshTokenInvalid))
 			return
 		}
 
-		userCollection := database.OpenCollection("users", client)
-		var user models.User
-		err = userCollection.FindOne(ctx, bson.D{{Key: "user_id", Value: claim.UserId}}).Decode(&user)
+		user, err := uc.users.FindByUserID(ctx, claim.UserId)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Usuario no encontrado"})
+			uc.metrics.Refresh(false)
+			apierror.Render(c, apierror.New(apierror.UserNotFound))
 			return
 		}
 
-		newToken, newRefreshToken, _ := utils.GenerateAllTokens(user.Email, user.FirstName, user.LastName, user.Role, user.UserID)
-		err = utils.UpdateAllTokens(user.UserID, newToken, newRefreshToken, client)
+		newToken, newRefreshToken, _ := uc.tokens.GenerateAllTokens(ctx, user.Email, user.FirstName, user.LastName, user.Role, user.UserID)
+		err = uc.users.UpdateTokens(ctx, user.UserID, newToken, newRefreshToken)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar tokens"})
+			apierror.Render(c, apierror.New(apierror.TokenUpdateFailed))
 			return
 		}
 
 		c.SetCookie("access_token", newToken, 86400, "/", "localhost", false, true)
 		c.SetCookie("refresh_token", newRefreshToken, 604800, "/", "localhost", false, true)
+		uc.metrics.Refresh(true)
 
-		c.JSON(http.StatusOK, gin.H{"message": "Tokens actualizados correctamente"})
+		c.JSON(http.StatusOK, messageResponse{Message: "Tokens actualizados correctamente"})
 	}
 }
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Límites de la fila "seguir viendo"
+const (
+	defaultContinueLimit = 10
+	maxContinueLimit     = 50
+)
+
+type WatchController struct {
+	watch  *repositories.WatchRepository
+	movies *repositories.MovieRepository
+}
+
+func NewWatchController(watch *repositories.WatchRepository, movies *repositories.MovieRepository) *WatchController {
+	return &WatchController{watch: watch, movies: movies}
+}
+
+// Cuerpo de un evento del reproductor
+type playbackRequest struct {
+	ImdbID          string               `json:"imdb_id" validate:"required"`
+	Event           models.PlaybackEvent `json:"event" validate:"required,oneof=started progress finished"`
+	PositionSeconds int                  `json:"position_seconds" validate:"min=0"`
+	DurationSeconds int                  `json:"duration_seconds" validate:"min=0"`
+}
+
+// Registrar un evento del reproductor (started, progress o finished)
+func (wc *WatchController) RecordPlayback() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		var req playbackRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierror.Render(c, apierror.New(apierror.InvalidBody))
+			return
+		}
+		if err := models.Validate(req); err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		if _, err := wc.movies.FindByImdbID(ctx, req.ImdbID); errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.MovieNotFound))
+			return
+		} else if err != nil {
+			apierror.Render(c, apierror.New(apierror.PlaybackSaveFailed))
+			return
+		}
+
+		entry, err := wc.watch.Record(ctx, userID, req.ImdbID, req.Event, req.PositionSeconds, req.DurationSeconds)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.PlaybackSaveFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, entry)
+	}
+}
+
+// Historial de reproducción del usuario, lo último visto primero
+func (wc *WatchController) History() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		page, pageSize, err := paginationParams(c)
+		if err != nil {
+			apierror.Render(c, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		history, err := wc.watch.History(ctx, userID, page, pageSize)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.HistoryFetchFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, history)
+	}
+}
+
+// Películas empezadas y sin terminar, para retomarlas donde quedaron
+func (wc *WatchController) ContinueWatching() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		limit := defaultContinueLimit
+		if value := c.Query("limit"); value != "" {
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 || limit > maxContinueLimit {
+				apierror.Render(c, apierror.New(apierror.ParamOutOfRange, "limit", maxContinueLimit))
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		entries, err := wc.watch.ContinueWatching(ctx, userID, limit)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.HistoryFetchFailed))
+			return
+		}
+
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// Quitar una película del historial
+func (wc *WatchController) DeleteHistoryEntry() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := utils.GetUserIdFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c, 100*time.Second)
+		defer cancel()
+
+		err = wc.watch.Delete(ctx, userID, c.Param("imdb_id"))
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierror.Render(c, apierror.New(apierror.HistoryEntryNotFound))
+			return
+		}
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.HistoryFetchFailed))
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -1,59 +1,58 @@
 package database
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"context"
 
-	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/v2/event"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-func Connect() *mongo.Client {
-	// Cargar variables de entorno
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Println("Advertencia: no se encontró el archivo .env")
-	}
-
-	uri := os.Getenv("MONGODB_URI")
-	if uri == "" {
-		log.Fatal("MONGODB_URI no está definido en el archivo .env")
-	}
-
-	fmt.Println("Conectando a MongoDB en URI:", uri)
-
-	clientOptions := options.Client().ApplyURI(uri)
-
-	client, err := mongo.Connect(clientOptions)
-	if err != nil {
-		log.Fatal("Error al conectar con MongoDB:", err)
-		return nil
-	}
+// Nombres de las colecciones usadas por la aplicación
+const (
+	UsersCollection              = "users"
+	MoviesCollection             = "movies"
+	GenresCollection             = "genres"
+	RatingsCollection            = "ratings"
+	ReviewsCollection            = "reviews"
+	AdminReviewHistoryCollection = "admin_review_history"
+	ListsCollection              = "lists"
+	WatchHistoryCollection       = "watch_history"
+	ActivityCollection           = "activity"
+	NeighboursCollection         = "movie_neighbours"
+)
 
-	return client
+// Connect crea el cliente de MongoDB para la URI indicada. extra agrega
+// opciones, por ejemplo los monitores de métricas.
+func Connect(uri string, extra ...*options.ClientOptions) (*mongo.Client, error) {
+	clientOptions := append([]*options.ClientOptions{options.Client().ApplyURI(uri)}, extra...)
+	return mongo.Connect(clientOptions...)
 }
 
-func OpenCollection(nombreColeccion string, client *mongo.Client) *mongo.Collection {
-	// Cargar variables de entorno
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Println("Advertencia: no se encontró el archivo .env")
-	}
-
-	databaseName := os.Getenv("DATABASE_NAME")
-	if databaseName == "" {
-		log.Fatal("DATABASE_NAME no está definido en el archivo .env")
+// CombineMonitors une varios monitores de comandos en uno, porque el cliente
+// acepta uno solo. Los monitores se llaman en el orden recibido.
+func CombineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Started != nil {
+					monitor.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, monitor := range monitors {
+				if monitor.Succeeded != nil {
+					monitor.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Failed != nil {
+					monitor.Failed(ctx, e)
+				}
+			}
+		},
 	}
-
-	fmt.Println("Usando la base de datos:", databaseName)
-
-	coleccion := client.Database(databaseName).Collection(nombreColeccion)
-	if coleccion == nil {
-		log.Println("No se pudo abrir la colección:", nombreColeccion)
-		return nil
-	}
-
-	return coleccion
 }
@@ -0,0 +1,152 @@
+// Package health implementa las sondas de vida (/healthz) y de disponibilidad
+// (/readyz) que usan la plataforma de despliegue y los balanceadores.
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/migrations"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Tiempo máximo de la sonda de disponibilidad, con MongoDB caído incluido
+const readyTimeout = 2 * time.Second
+
+// Estados de una comprobación y del conjunto
+const (
+	StatusOK      = "ok"
+	StatusFail    = "fail"
+	StatusSkipped = "skipped"
+)
+
+// Check es el resultado de comprobar una dependencia.
+type Check struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report es la respuesta de /readyz y, sin comprobaciones, la de /healthz.
+type Report struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks,omitempty"`
+}
+
+type Checker struct {
+	cfg      *config.Config
+	db       *mongo.Database
+	draining atomic.Bool
+}
+
+func NewChecker(cfg *config.Config, db *mongo.Database) *Checker {
+	return &Checker{cfg: cfg, db: db}
+}
+
+// Drain hace fallar la disponibilidad desde ahora: el servidor se está
+// apagando y el balanceador debe dejar de enviarle pedidos.
+func (h *Checker) Drain() {
+	h.draining.Store(true)
+}
+
+// Ready comprueba la configuración, la conexión con MongoDB y los índices
+// requeridos. Si MongoDB no responde los índices no se comprueban. Después
+// de Drain siempre falla.
+func (h *Checker) Ready(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Check, 4)}
+	record := func(name string, check Check) {
+		if check.Status == StatusFail {
+			report.Status = StatusFail
+		}
+		report.Checks[name] = check
+	}
+
+	if h.draining.Load() {
+		record("shutdown", Check{Status: StatusFail, Error: "el servidor se está apagando"})
+	}
+
+	// /readyz es público: los detalles de cada falla (variables, índices,
+	// direcciones de los servidores en los errores del driver) van al log y
+	// la respuesta solo dice qué comprobación falló
+	record("config", run(func() error {
+		if missing := h.cfg.Missing(); len(missing) > 0 {
+			logging.FromContext(ctx).Warn("faltan variables de configuración", "variables", strings.Join(missing, ", "))
+			return errors.New("faltan variables de configuración")
+		}
+		return nil
+	}))
+
+	mongodb := run(func() error {
+		if err := h.db.Client().Ping(ctx, nil); err != nil {
+			logging.FromContext(ctx).Warn("MongoDB no responde", "error", err)
+			return errors.New("MongoDB no responde")
+		}
+		return nil
+	})
+	record("mongodb", mongodb)
+
+	if mongodb.Status != StatusOK {
+		record("indexes", Check{Status: StatusSkipped})
+		return report
+	}
+	record("indexes", run(func() error {
+		missing, err := migrations.MissingIndexes(ctx, h.db)
+		if err != nil {
+			logging.FromContext(ctx).Warn("no se pudieron comprobar los índices", "error", err)
+			return errors.New("no se pudieron comprobar los índices")
+		}
+		if len(missing) > 0 {
+			names := make([]string, len(missing))
+			for i, ref := range missing {
+				names[i] = ref.Collection + "." + ref.Name
+			}
+			logging.FromContext(ctx).Warn("faltan índices", "indexes", strings.Join(names, ", "))
+			return errors.New("faltan índices (¿migraciones pendientes?)")
+		}
+		return nil
+	}))
+	return report
+}
+
+func run(check func() error) Check {
+	start := time.Now()
+	err := check()
+	result := Check{Status: StatusOK, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Liveness responde 200 mientras el proceso atienda pedidos; no consulta
+// dependencias para que una caída de MongoDB no provoque reinicios.
+func Liveness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, Report{Status: StatusOK})
+	}
+}
+
+// Readiness responde 200 si todas las comprobaciones pasan y 503 si alguna
+// falla, con el detalle por dependencia.
+func (h *Checker) Readiness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := h.Ready(c)
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		c.Header("Cache-Control", "no-store")
+		c.JSON(status, report)
+	}
+}
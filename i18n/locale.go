@@ -0,0 +1,33 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+// Idiomas soportados. El primero es el idioma por defecto.
+const (
+	Spanish = "es"
+	English = "en"
+	Default = Spanish
+)
+
+// Supported lista los idiomas soportados en orden de preferencia.
+var Supported = []string{Spanish, English}
+
+var matcher = language.NewMatcher([]language.Tag{language.Spanish, language.English})
+
+// Negotiate elige el idioma de la respuesta. El parámetro lang tiene
+// prioridad sobre el encabezado Accept-Language; si ninguno coincide con un
+// idioma soportado se usa el idioma por defecto.
+func Negotiate(lang, acceptLanguage string) string {
+	_, index := language.MatchStrings(matcher, lang, acceptLanguage)
+	return Supported[index]
+}
+
+// IsSupported indica si el código de idioma es uno de los soportados.
+func IsSupported(locale string) bool {
+	for _, supported := range Supported {
+		if supported == locale {
+			return true
+		}
+	}
+	return false
+}
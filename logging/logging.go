@@ -0,0 +1,47 @@
+// Package logging arma el logger estructurado de la aplicación: JSON sobre
+// log/slog, con nivel configurable y datos sensibles siempre ocultos.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// New crea un logger JSON que escribe en w los mensajes de nivel level o más.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}))
+}
+
+// ParseLevel interpreta debug, info, warn o error, sin distinguir mayúsculas.
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return 0, fmt.Errorf("nivel de log no válido: %q (debug, info, warn o error)", value)
+	}
+	return level, nil
+}
+
+// Clave del logger del pedido en el contexto de gin
+const contextKey = "logger"
+
+// Set guarda el logger del pedido, para que los siguientes handlers lo usen.
+func Set(c *gin.Context, logger *slog.Logger) {
+	c.Set(contextKey, logger)
+}
+
+// FromContext devuelve el logger del pedido, con su request_id y ruta, o el
+// logger por defecto si el contexto no tiene uno.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Texto que reemplaza a los datos ocultos
+const Redacted = "[REDACTED]"
+
+// Partes de nombres de atributos cuyo valor nunca se escribe
+var sensitiveKeys = []string{
+	"token", "password", "passwd", "secret", "cookie", "authorization", "api_key", "api-key", "apikey",
+}
+
+// Tokens que pueden aparecer dentro de un texto: JWT y encabezados Bearer
+var sensitiveValues = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*|(?i:bearer)\s+[A-Za-z0-9._~+/=-]+`)
+
+// redactAttr oculta el valor de los atributos con nombres sensibles o que
+// están dentro de un grupo con nombre sensible (por ejemplo "cookies"), y los
+// tokens que aparezcan en textos y errores.
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	if IsSensitive(attr.Key) || slices.ContainsFunc(groups, IsSensitive) {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(RedactText(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			attr.Value = slog.StringValue(RedactText(err.Error()))
+		}
+	}
+	return attr
+}
+
+// IsSensitive indica si un atributo, encabezado o campo con ese nombre lleva
+// datos que no deben registrarse.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactText reemplaza los tokens que aparezcan en el texto.
+func RedactText(text string) string {
+	return sensitiveValues.ReplaceAllString(text, Redacted)
+}
@@ -0,0 +1,27 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Mailer envía correos electrónicos a los usuarios.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// LogMailer escribe los correos en el log en lugar de enviarlos.
+// Se usa en desarrollo y en pruebas.
+type LogMailer struct {
+	From   string
+	Logger *slog.Logger
+}
+
+func NewLogMailer(from string, logger *slog.Logger) *LogMailer {
+	return &LogMailer{From: from, Logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.Logger.InfoContext(ctx, "correo", "from", m.From, "to", to, "subject", subject)
+	return nil
+}
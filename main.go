@@ -4,76 +4,225 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/app"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/buildinfo"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
-	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/routes"
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/metrics"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/migrations"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/tracing"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
-func init() {
-	if err := godotenv.Load(".env"); err != nil {
-		log.Println("Advertencia: no se encontró el archivo .env")
-	}
-}
+const usage = `Uso: peliculapp [comando]
+
+Comandos:
+  serve                  Levanta el servidor HTTP (por defecto)
+  version                Muestra la versión y el commit del binario
+  openapi [--check]      Imprime el documento OpenAPI; con --check falla si
+                         hay rutas sin documentar
+  migrate up             Aplica las migraciones pendientes
+  migrate down [n]       Revierte las últimas n migraciones (por defecto 1)
+  migrate status         Muestra el estado de las migraciones
+  seed [opciones]        Carga géneros, películas y usuarios desde "json files"
+                         --dry-run  --reset  --test-docs  --dir <directorio>
+  import [opciones] <archivo>
+                         Importa películas (json, ndjson o csv) por imdb_id
+                         --format <f>  --mode best-effort|all-or-nothing
+  export [opciones]      Exporta el catálogo o una búsqueda
+                         --format <f>  --out <archivo>  --query <q>  --genres <g1,g2>
+  ratings recompute      Recalcula user_score desde las calificaciones
+  recommend build        Calcula las películas vecinas para las recomendaciones
+  recommend evaluate [opciones]
+                         Mide la precisión@k de las recomendaciones
+                         --k <n>  --holdout <0-1>  --min-interactions <n>  --seed <n>`
 
 func main() {
-	router := gin.Default()
-
-	router.GET("/hello", func(c *gin.Context) {
-		c.String(200, "Hello, PeliculApp!")
-	})
-
-	allowedOrigins := os.Getenv("ALLOWED_ORIGINS")
-	var origins []string
-	if allowedOrigins != "" {
-		origins = strings.Split(allowedOrigins, ",")
-		for i := range origins {
-			origins[i] = strings.TrimSpace(origins[i])
-			log.Println("Allowed Origin:", origins[i])
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	// La versión no depende de la configuración
+	if command == "version" || command == "--version" {
+		info := buildinfo.Get()
+		fmt.Printf("peliculapp %s (commit %s, compilado %s, %s)\n", info.Version, orUnknown(info.Commit), orUnknown(info.BuildTime), info.GoVersion)
+		return
+	}
+	// El documento OpenAPI tampoco: el router se arma sin conectarse a la base
+	if command == "openapi" {
+		if err := runOpenAPI(args); err != nil {
+			log.Fatal(err)
 		}
-	} else {
-		origins = []string{"http://localhost:5173"}
-		log.Println("Allowed Origin: http://localhost:5173")
+		return
 	}
 
-	config := cors.Config{
-		AllowOrigins:     origins,
-		AllowMethods:     []string{"GET", "POST", "PATCH", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
 	}
-	router.Use(cors.New(config))
-	router.Use(gin.Logger())
+	// Los mensajes del paquete log también salen por este logger
+	slog.SetDefault(logging.New(os.Stdout, cfg.LogLevel))
 
-	// Conexión a MongoDB
-	client := database.Connect()
+	switch command {
+	case "serve":
+		err = runServer(cfg)
+	case "migrate":
+		err = runMigrate(cfg, args)
+	case "seed":
+		err = runSeed(cfg, args)
+	case "import":
+		err = runImport(cfg, args)
+	case "export":
+		err = runExport(cfg, args)
+	case "recommend":
+		err = runRecommend(cfg, args)
+	case "ratings":
+		err = runRatings(cfg, args)
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Println(usage)
+		err = fmt.Errorf("comando desconocido: %s", command)
+	}
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "desconocido"
+	}
+	return value
+}
+
+// connect abre la conexión a MongoDB y verifica que responda.
+func connect(cfg *config.Config, extra ...*options.ClientOptions) (*mongo.Client, error) {
+	client, err := database.Connect(cfg.MongoURI, extra...)
+	if err != nil {
+		return nil, fmt.Errorf("error al conectar con MongoDB: %w", err)
+	}
 	if err := client.Ping(context.Background(), nil); err != nil {
-		log.Fatalf("Falló la conexión a MongoDB: %v", err)
+		return nil, fmt.Errorf("falló la conexión a MongoDB: %w", err)
+	}
+	return client, nil
+}
+
+func disconnect(client *mongo.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Disconnect(ctx); err != nil {
+		slog.Error("no se pudo desconectar de MongoDB", "error", err)
+	}
+}
+
+func runServer(cfg *config.Config) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracesExporter, cfg.ServiceName)
+	if err != nil {
+		return err
 	}
 	defer func() {
-		if err := client.Disconnect(context.Background()); err != nil {
-			log.Fatalf("No se pudo desconectar de MongoDB: %v", err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("no se pudieron enviar las trazas pendientes", "error", err)
 		}
 	}()
 
-	// Rutas
-	routes.SetupUnProtectedRoutes(router, client)
-	routes.SetupProtectedRoutes(router, client)
+	// Las métricas se crean antes de conectar para medir también a MongoDB
+	m := metrics.New()
+	monitors := options.Client().
+		SetMonitor(database.CombineMonitors(m.CommandMonitor(), tracing.CommandMonitor())).
+		SetPoolMonitor(m.PoolMonitor())
+
+	// Conexión a MongoDB
+	client, err := connect(cfg, monitors)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	db := client.Database(cfg.DatabaseName)
+
+	if cfg.MigrateOnStart {
+		applied, err := migrations.NewMigrator(db, migrations.All).Up(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			slog.Info("migraciones aplicadas", "versions", applied)
+		}
+	}
+
+	application := app.New(cfg, db, m)
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	application.Start(jobs)
+
+	server := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           application.Router(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Levantar servidor
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("servidor corriendo", "port", cfg.Port, "version", buildinfo.Version)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("falló el inicio del servidor: %w", err)
+	case <-signals.Done():
 	}
-	log.Printf("Servidor corriendo en puerto %s", port)
-	if err := router.Run(":" + port); err != nil {
-		fmt.Println("Falló el inicio del servidor:", err)
+	// Una segunda señal termina el proceso sin esperar
+	stop()
+
+	return shutdown(cfg, server, application, stopJobs)
+}
+
+// Límite para leer los encabezados, aparte de HTTP_READ_TIMEOUT que incluye el cuerpo
+const readHeaderTimeout = 10 * time.Second
+
+// shutdown apaga el servidor en orden: /readyz empieza a fallar para que el
+// balanceador deje de enviar pedidos, luego se dejan de aceptar conexiones y
+// se esperan los pedidos en curso, y por último se detienen los trabajos en
+// segundo plano. La desconexión de MongoDB queda para runServer.
+func shutdown(cfg *config.Config, server *http.Server, application *app.App, stopJobs context.CancelFunc) error {
+	slog.Info("apagando el servidor", "delay", cfg.ShutdownDelay, "timeout", cfg.ShutdownTimeout)
+	application.Health.Drain()
+	time.Sleep(cfg.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	err := server.Shutdown(ctx)
+	if err != nil {
+		// Se cortan las conexiones que siguen abiertas
+		server.Close()
+		err = fmt.Errorf("quedaron pedidos sin terminar al apagar: %w", err)
 	}
+
+	stopJobs()
+	application.Wait()
+	slog.Info("servidor detenido")
+	return err
 }
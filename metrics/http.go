@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Middleware mide cada pedido por método, ruta registrada y estado. Los
+// pedidos sin ruta se agrupan como "unmatched" para no crear una serie por URL.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		m.httpInFlight.Inc()
+		defer m.httpInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		m.httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler sirve las métricas en el formato de Prometheus. Si token no está
+// vacío exige el encabezado "Authorization: Bearer <token>".
+func (m *Metrics) Handler(token string) gin.HandlerFunc {
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	return func(c *gin.Context) {
+		if token != "" {
+			given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				c.Header("WWW-Authenticate", "Bearer")
+				apierror.Render(c, apierror.New(apierror.TokenInvalid))
+				return
+			}
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,119 @@
+// Package metrics expone métricas de Prometheus de los pedidos HTTP, las
+// operaciones de MongoDB y los eventos de autenticación.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Prefijo de todas las métricas de la aplicación
+const namespace = "peliculapp"
+
+// Metrics agrupa los colectores en un registro propio, para no mezclarlos
+// con el registro global de la librería.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight prometheus.Gauge
+
+	mongoDuration *prometheus.HistogramVec
+	mongoErrors   *prometheus.CounterVec
+	poolOpen      prometheus.Gauge
+	poolInUse     prometheus.Gauge
+	poolFailures  *prometheus.CounterVec
+
+	logins       *prometheus.CounterVec
+	refreshes    *prometheus.CounterVec
+	authRejected *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "http", Name: "requests_total",
+			Help: "Pedidos HTTP atendidos, por método, ruta y estado.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: "http", Name: "request_duration_seconds",
+			Help:    "Duración de los pedidos HTTP, por método y ruta.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "http", Name: "requests_in_flight",
+			Help: "Pedidos HTTP en curso.",
+		}),
+
+		mongoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Subsystem: "mongodb", Name: "command_duration_seconds",
+			Help:    "Duración de los comandos de MongoDB, por comando.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"command"}),
+		mongoErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "mongodb", Name: "command_errors_total",
+			Help: "Comandos de MongoDB que fallaron, por comando.",
+		}, []string{"command"}),
+		poolOpen: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "mongodb", Name: "pool_connections_open",
+			Help: "Conexiones abiertas en el pool de MongoDB.",
+		}),
+		poolInUse: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace, Subsystem: "mongodb", Name: "pool_connections_in_use",
+			Help: "Conexiones del pool de MongoDB tomadas por una operación.",
+		}),
+		poolFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "mongodb", Name: "pool_checkout_failures_total",
+			Help: "Intentos fallidos de tomar una conexión del pool, por motivo.",
+		}, []string{"reason"}),
+
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "auth", Name: "logins_total",
+			Help: "Inicios de sesión, por resultado (success o failure).",
+		}, []string{"result"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "auth", Name: "token_refreshes_total",
+			Help: "Renovaciones de token, por resultado (success o failure).",
+		}, []string{"result"}),
+		authRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Subsystem: "auth", Name: "rejected_total",
+			Help: "Pedidos rechazados por el middleware de autenticación, por motivo.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.mongoDuration, m.mongoErrors, m.poolOpen, m.poolInUse, m.poolFailures,
+		m.logins, m.refreshes, m.authRejected,
+	)
+	return m
+}
+
+// Resultado de un evento de autenticación
+func result(ok bool) string {
+	if ok {
+		return "success"
+	}
+	return "failure"
+}
+
+// Login cuenta un inicio de sesión.
+func (m *Metrics) Login(ok bool) {
+	m.logins.WithLabelValues(result(ok)).Inc()
+}
+
+// Refresh cuenta una renovación de token.
+func (m *Metrics) Refresh(ok bool) {
+	m.refreshes.WithLabelValues(result(ok)).Inc()
+}
+
+// AuthRejected cuenta un pedido rechazado por falta de token (missing) o por
+// un token no válido (invalid).
+func (m *Metrics) AuthRejected(reason string) {
+	m.authRejected.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+// CommandMonitor mide la duración y los errores de los comandos de MongoDB.
+func (m *Metrics) CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			m.mongoDuration.WithLabelValues(e.CommandName).Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			m.mongoDuration.WithLabelValues(e.CommandName).Observe(e.Duration.Seconds())
+			m.mongoErrors.WithLabelValues(e.CommandName).Inc()
+		},
+	}
+}
+
+// PoolMonitor sigue las conexiones abiertas y en uso del pool de MongoDB.
+func (m *Metrics) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: m.poolEvent}
+}
+
+func (m *Metrics) poolEvent(e *event.PoolEvent) {
+	switch e.Type {
+	case event.ConnectionCreated:
+		m.poolOpen.Inc()
+	case event.ConnectionClosed:
+		m.poolOpen.Dec()
+	case event.ConnectionCheckedOut:
+		m.poolInUse.Inc()
+	case event.ConnectionCheckedIn:
+		m.poolInUse.Dec()
+	case event.ConnectionCheckOutFailed:
+		m.poolFailures.WithLabelValues(e.Reason).Inc()
+	}
+}
@@ -1,46 +1,34 @@
 package middleware
 
 import (
-	"log"
-	"net/http"
-
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/metrics"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleWare() gin.HandlerFunc {
+func AuthMiddleWare(tokens *utils.TokenService, m *metrics.Metrics) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Obtener token de acceso desde la cookie
 		token, err := utils.GetAccessToken(c)
-		log.Println("Auth token recibido:", token) // debug del token
-		if err != nil {
-			log.Println("Error al obtener token:", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Error al obtener token: " + err.Error()})
-			c.Abort()
-			return
-		}
-
-		if token == "" {
-			log.Println("Token vacío recibido")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No se proporcionó token"})
-			c.Abort()
+		if err != nil || token == "" {
+			m.AuthRejected("missing")
+			apierror.Render(c, apierror.New(apierror.TokenMissing))
 			return
 		}
 
 		// Validar token
-		claims, err := utils.ValidateToken(token)
+		claims, err := tokens.ValidateToken(c, token)
 		if err != nil {
-			log.Println("Token inválido:", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token inválido: " + err.Error()})
-			c.Abort()
+			logging.FromContext(c).Debug("token inválido", "error", err)
+			m.AuthRejected("invalid")
+			apierror.Render(c, apierror.New(apierror.TokenInvalid))
 			return
 		}
 
-		log.Println("Token válido para userId:", claims.UserId, "role:", claims.Role)
-
 		// Guardar información del usuario en el contexto
-		c.Set("userId", claims.UserId)
-		c.Set("role", claims.Role)
+		identify(c, claims.UserId, claims.Role)
 
 		c.Next()
 	}
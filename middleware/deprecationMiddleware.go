@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated marca las rutas como obsoletas desde since (encabezado
+// Deprecation, RFC 9745) y anuncia que se retiran en sunset (Sunset, RFC 8594).
+// successor devuelve la ruta que reemplaza a la del pedido, con la sintaxis de
+// gin: el encabezado Link la completa con los parámetros y la query del pedido.
+func Deprecated(since, sunset time.Time, successor func(method, route string) string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(since.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Sunset", sunsetDate)
+
+		if path := successor(c.Request.Method, c.FullPath()); path != "" {
+			link := fillParams(path, c.Params)
+			if c.Request.URL.RawQuery != "" {
+				link += "?" + c.Request.URL.RawQuery
+			}
+			c.Header("Link", "<"+link+`>; rel="successor-version"`)
+		}
+
+		c.Next()
+	}
+}
+
+// fillParams reemplaza los segmentos :nombre de path por el valor del parámetro.
+func fillParams(path string, params gin.Params) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = url.PathEscape(params.ByName(name))
+		}
+	}
+	return strings.Join(segments, "/")
+}
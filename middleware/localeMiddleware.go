@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale elige el idioma del pedido (?lang= o Accept-Language) y lo guarda
+// en el contexto para que los handlers localicen la respuesta.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+
+		c.Set("locale", locale)
+		c.Header("Content-Language", locale)
+		c.Header("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
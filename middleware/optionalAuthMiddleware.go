@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OptionalAuth identifica al usuario si el pedido trae un token válido, pero
+// no rechaza a los anónimos. Sirve para rutas públicas que agregan datos del
+// usuario (por ejemplo su calificación) cuando hay sesión.
+func OptionalAuth(tokens *utils.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := utils.GetAccessToken(c)
+		if err == nil && token != "" {
+			if claims, err := tokens.ValidateToken(c, token); err == nil {
+				identify(c, claims.UserId, claims.Role)
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Encabezado con el identificador del pedido
+const RequestIDHeader = "X-Request-ID"
+
+// Identificadores aceptados del cliente: cortos y sin caracteres raros, para
+// que no ensucien los logs
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestLogger asigna a cada pedido un request_id (el del encabezado
+// X-Request-ID si es válido), lo devuelve en la respuesta y deja en el
+// contexto un logger con el id, la ruta y el trace_id si hay traza; la
+// autenticación le agrega el usuario. Al terminar registra el pedido con el
+// estado y la duración: error para 5xx, warn para 4xx.
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = rand.Text()
+		}
+		c.Set("requestId", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requestLogger := logger.With("request_id", requestID, "method", c.Request.Method, "route", route)
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+		}
+		logging.Set(c, requestLogger)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+
+		// Solo la ruta, sin la query: puede traer datos del usuario
+		attrs := []any{
+			"path", c.Request.URL.Path,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+			"bytes", c.Writer.Size(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+		logging.FromContext(c).Log(c, level, "pedido", attrs...)
+	}
+}
+
+// identify agrega el usuario al logger del pedido.
+func identify(c *gin.Context, userID, role string) {
+	c.Set("userId", userID)
+	c.Set("role", role)
+	logging.Set(c, logging.FromContext(c).With("user_id", userID))
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole permite el acceso solo a usuarios con el rol indicado.
+// Debe usarse después de AuthMiddleWare.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, err := utils.GetRoleFromContext(c)
+		if err != nil {
+			apierror.Render(c, apierror.New(apierror.RoleMissing))
+			return
+		}
+
+		if userRole != role {
+			apierror.Render(c, apierror.New(apierror.RoleRequired, role))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/migrations"
+)
+
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("falta el subcomando: migrate up|down|status")
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	migrator := migrations.NewMigrator(client.Database(cfg.DatabaseName), migrations.All)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, version := range applied {
+			fmt.Printf("Aplicada migración %d\n", version)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No hay migraciones pendientes")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("cantidad de pasos inválida: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		for _, version := range reverted {
+			fmt.Printf("Revertida migración %d\n", version)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pendiente"
+			if status.Applied {
+				state = "aplicada " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%3d  %-60s %s\n", status.Version, status.Description, state)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("subcomando de migrate desconocido: %s", args[0])
+	}
+}
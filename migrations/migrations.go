@@ -0,0 +1,396 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Nombres de los índices creados por las migraciones
+const (
+	UsersEmailIndex   = "users_email_unique"
+	UsersUserIDIndex  = "users_user_id_unique"
+	MoviesImdbIDIndex = "movies_imdb_id_unique"
+	MoviesTextIndex   = "movies_text"
+	MoviesGenreIndex  = "movies_genre_id"
+	MoviesFilterIndex = "movies_ranking_year"
+	GenresIDIndex     = "genres_genre_id_unique"
+	GenresNameIndex   = "genres_genre_name_unique"
+	RatingsUserIndex  = "ratings_user_movie_unique"
+	RatingsMovieIndex = "ratings_imdb_id"
+	MoviesScoreIndex  = "movies_user_score"
+	ReviewsUserIndex  = "reviews_user_movie_unique"
+	ReviewsMovieIndex = "reviews_movie_status_created"
+	ReviewsQueueIndex = "reviews_status_created"
+	HistoryIndex      = "admin_review_history_version_unique"
+	ListsSlugIndex    = "lists_slug_unique"
+	ListsUserIndex    = "lists_user_kind"
+	ListsWatchIndex   = "lists_watchlist_unique"
+	WatchUserIndex    = "watch_history_user_movie_unique"
+	WatchRecentIndex  = "watch_history_user_last_watched"
+	ActivityTTLIndex  = "activity_created_at_ttl"
+	MoviesHourlyIndex = "movies_popularity_hourly"
+	MoviesDailyIndex  = "movies_popularity_daily"
+	MoviesWeeklyIndex = "movies_popularity_weekly"
+	NeighboursIndex   = "movie_neighbours_imdb_id_unique"
+)
+
+// Tiempo que se guardan los eventos de actividad: la ventana semanal más un día
+const activityRetention = 8 * 24 * time.Hour
+
+// All contiene todas las migraciones de la aplicación en orden.
+// Las nuevas migraciones se agregan al final con la siguiente versión.
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "índices únicos de usuarios",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, database.UsersCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "email", Value: 1}},
+					Options: options.Index().SetName(UsersEmailIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}},
+					Options: options.Index().SetName(UsersUserIDIndex).SetUnique(true),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db, database.UsersCollection, UsersEmailIndex, UsersUserIDIndex)
+		},
+	},
+	{
+		Version:     2,
+		Description: "índice único de películas por imdb_id",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, database.MoviesCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(MoviesImdbIDIndex).SetUnique(true),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db, database.MoviesCollection, MoviesImdbIDIndex)
+		},
+	},
+	{
+		Version:     3,
+		Description: "índice de texto para la búsqueda de películas",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, database.MoviesCollection,
+				moviesTextIndex(false),
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db, database.MoviesCollection, MoviesTextIndex)
+		},
+	},
+	{
+		Version:     4,
+		Description: "validadores JSON-schema de usuarios, películas y géneros",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			// Los usuarios anteriores guardaban la fecha de los tokens como update_at
+			if err := renameField(ctx, db, database.UsersCollection, "update_at", "updated_at"); err != nil {
+				return err
+			}
+			if err := setValidator(ctx, db, database.UsersCollection, userSchema); err != nil {
+				return err
+			}
+			if err := setValidator(ctx, db, database.MoviesCollection, movieSchema); err != nil {
+				return err
+			}
+			return setValidator(ctx, db, database.GenresCollection, genreSchema)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, name := range []string{database.UsersCollection, database.MoviesCollection, database.GenresCollection} {
+				if err := setValidator(ctx, db, name, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version:     5,
+		Description: "índices para los filtros del catálogo",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, database.MoviesCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "genre.genre_id", Value: 1}},
+					Options: options.Index().SetName(MoviesGenreIndex),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "ranking.ranking_value", Value: 1}, {Key: "release_year", Value: 1}},
+					Options: options.Index().SetName(MoviesFilterIndex),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db, database.MoviesCollection, MoviesGenreIndex, MoviesFilterIndex)
+		},
+	},
+	{
+		Version:     6,
+		Description: "índices únicos de géneros por id y nombre",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return createIndexes(ctx, db, database.GenresCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "genre_id", Value: 1}},
+					Options: options.Index().SetName(GenresIDIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "genre_name", Value: 1}},
+					Options: options.Index().
+						SetName(GenresNameIndex).
+						SetUnique(true).
+						SetCollation(&options.Collation{Locale: "es", Strength: 1}),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndexes(ctx, db, database.GenresCollection, GenresIDIndex, GenresNameIndex)
+		},
+	},
+	{
+		Version:     7,
+		Description: "índice de texto con títulos y descripciones traducidos",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndexes(ctx, db, database.MoviesCollection, MoviesTextIndex); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.MoviesCollection, moviesTextIndex(true))
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndexes(ctx, db, database.MoviesCollection, MoviesTextIndex); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.MoviesCollection, moviesTextIndex(false))
+		},
+	},
+	{
+		Version:     8,
+		Description: "calificaciones de usuarios y orden por puntaje",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := setValidator(ctx, db, database.RatingsCollection, ratingSchema); err != nil {
+				return err
+			}
+			err := createIndexes(ctx, db, database.RatingsCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(RatingsUserIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(RatingsMovieIndex),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.MoviesCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_score.average", Value: -1}, {Key: "user_score.count", Value: -1}},
+					Options: options.Index().SetName(MoviesScoreIndex),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndexes(ctx, db, database.MoviesCollection, MoviesScoreIndex); err != nil {
+				return err
+			}
+			return db.Collection(database.RatingsCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     9,
+		Description: "reseñas de usuarios y cola de moderación",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := setValidator(ctx, db, database.ReviewsCollection, reviewSchema); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.ReviewsCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(ReviewsUserIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "imdb_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+					Options: options.Index().SetName(ReviewsMovieIndex),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+					Options: options.Index().SetName(ReviewsQueueIndex),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection(database.ReviewsCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     10,
+		Description: "historial de versiones de la reseña del administrador",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := ensureCollection(ctx, db, database.AdminReviewHistoryCollection); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.AdminReviewHistoryCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "imdb_id", Value: 1}, {Key: "version", Value: -1}},
+					Options: options.Index().SetName(HistoryIndex).SetUnique(true),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection(database.AdminReviewHistoryCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     11,
+		Description: "listas de películas y lista de pendientes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := setValidator(ctx, db, database.ListsCollection, listSchema); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.ListsCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "slug", Value: 1}},
+					Options: options.Index().SetName(ListsSlugIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "kind", Value: 1}, {Key: "created_at", Value: 1}},
+					Options: options.Index().SetName(ListsUserIndex),
+				},
+				// Una sola lista de pendientes por usuario
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "user_id", Value: 1}},
+					Options: options.Index().
+						SetName(ListsWatchIndex).
+						SetUnique(true).
+						SetPartialFilterExpression(bson.M{"kind": "watchlist"}),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection(database.ListsCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     12,
+		Description: "historial de reproducción y seguir viendo",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := setValidator(ctx, db, database.WatchHistoryCollection, watchSchema); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.WatchHistoryCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(WatchUserIndex).SetUnique(true),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "last_watched_at", Value: -1}},
+					Options: options.Index().SetName(WatchRecentIndex),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection(database.WatchHistoryCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     13,
+		Description: "actividad de usuarios y popularidad de películas",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := ensureCollection(ctx, db, database.ActivityCollection); err != nil {
+				return err
+			}
+			err := createIndexes(ctx, db, database.ActivityCollection,
+				mongo.IndexModel{
+					Keys: bson.D{{Key: "created_at", Value: 1}},
+					Options: options.Index().
+						SetName(ActivityTTLIndex).
+						SetExpireAfterSeconds(int32(activityRetention.Seconds())),
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.MoviesCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "popularity.hourly", Value: -1}},
+					Options: options.Index().SetName(MoviesHourlyIndex),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "popularity.daily", Value: -1}},
+					Options: options.Index().SetName(MoviesDailyIndex),
+				},
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "popularity.weekly", Value: -1}},
+					Options: options.Index().SetName(MoviesWeeklyIndex),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndexes(ctx, db, database.MoviesCollection, MoviesHourlyIndex, MoviesDailyIndex, MoviesWeeklyIndex); err != nil {
+				return err
+			}
+			return db.Collection(database.ActivityCollection).Drop(ctx)
+		},
+	},
+	{
+		Version:     14,
+		Description: "películas vecinas para las recomendaciones",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := ensureCollection(ctx, db, database.NeighboursCollection); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db, database.NeighboursCollection,
+				mongo.IndexModel{
+					Keys:    bson.D{{Key: "imdb_id", Value: 1}},
+					Options: options.Index().SetName(NeighboursIndex).SetUnique(true),
+				},
+			)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection(database.NeighboursCollection).Drop(ctx)
+		},
+	},
+}
+
+// moviesTextIndex arma el índice de texto de películas. Con translations
+// incluye el título y la descripción en inglés con los mismos pesos.
+func moviesTextIndex(translations bool) mongo.IndexModel {
+	keys := bson.D{
+		{Key: "title", Value: "text"},
+		{Key: "description", Value: "text"},
+		{Key: "admin_review", Value: "text"},
+	}
+	weights := bson.D{
+		{Key: "title", Value: 10},
+		{Key: "description", Value: 3},
+		{Key: "admin_review", Value: 1},
+	}
+	if translations {
+		keys = append(keys,
+			bson.E{Key: "translations.en.title", Value: "text"},
+			bson.E{Key: "translations.en.description", Value: "text"},
+		)
+		weights = append(weights,
+			bson.E{Key: "translations.en.title", Value: 10},
+			bson.E{Key: "translations.en.description", Value: 3},
+		)
+	}
+	return mongo.IndexModel{
+		Keys: keys,
+		Options: options.Index().
+			SetName(MoviesTextIndex).
+			SetDefaultLanguage("spanish").
+			SetWeights(weights),
+	}
+}
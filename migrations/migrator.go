@@ -0,0 +1,233 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Colección donde se registran las migraciones aplicadas
+const collectionName = "migrations"
+
+// Migration es un cambio versionado del esquema de la base de datos.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record es el documento guardado en la colección migrations.
+type Record struct {
+	Version     int       `bson:"version" json:"version"`
+	Description string    `bson:"description" json:"description"`
+	AppliedAt   time.Time `bson:"applied_at" json:"applied_at"`
+}
+
+// Status indica si una migración ya fue aplicada.
+type Status struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+type Migrator struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	migrations []Migration
+}
+
+// NewMigrator crea un migrador con las migraciones indicadas, ordenadas por versión.
+func NewMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{
+		db:         db,
+		collection: db.Collection(collectionName),
+		migrations: sorted,
+	}
+}
+
+// Up aplica todas las migraciones pendientes y devuelve las versiones aplicadas.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, migration := range m.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migración %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		record := Record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		}
+		if _, err := m.collection.InsertOne(ctx, record); err != nil {
+			return done, fmt.Errorf("no se pudo registrar la migración %d: %w", migration.Version, err)
+		}
+		done = append(done, migration.Version)
+	}
+	return done, nil
+}
+
+// Down revierte las últimas `steps` migraciones aplicadas y devuelve sus versiones.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if !applied[migration.Version] {
+			continue
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("la migración %d no se puede revertir", migration.Version)
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			return done, fmt.Errorf("revertir migración %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		if _, err := m.collection.DeleteOne(ctx, bson.D{{Key: "version", Value: migration.Version}}); err != nil {
+			return done, fmt.Errorf("no se pudo borrar el registro de la migración %d: %w", migration.Version, err)
+		}
+		done = append(done, migration.Version)
+	}
+	return done, nil
+}
+
+// Status lista todas las migraciones conocidas con su estado.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	records, err := m.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := records[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]bool, error) {
+	records, err := m.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]bool, len(records))
+	for version := range records {
+		applied[version] = true
+	}
+	return applied, nil
+}
+
+func (m *Migrator) records(ctx context.Context) (map[int]Record, error) {
+	cursor, err := m.collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []Record
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	records := make(map[int]Record, len(list))
+	for _, record := range list {
+		records[record.Version] = record
+	}
+	return records, nil
+}
+
+// Código de error de MongoDB cuando la colección ya existe
+const namespaceExistsCode = 48
+
+// ensureCollection crea la colección si todavía no existe.
+func ensureCollection(ctx context.Context, db *mongo.Database, name string) error {
+	err := db.CreateCollection(ctx, name)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.HasErrorCode(namespaceExistsCode) {
+		return nil
+	}
+	return err
+}
+
+// setValidator reemplaza el validador JSON-schema de una colección.
+// Un esquema nil elimina la validación.
+func setValidator(ctx context.Context, db *mongo.Database, name string, schema bson.M) error {
+	if err := ensureCollection(ctx, db, name); err != nil {
+		return err
+	}
+
+	validator := bson.M{}
+	if schema != nil {
+		validator = bson.M{"$jsonSchema": schema}
+	}
+
+	command := bson.D{
+		{Key: "collMod", Value: name},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}
+	return db.RunCommand(ctx, command).Err()
+}
+
+// renameField renombra from a to en los documentos de la colección. Si un
+// documento ya tiene los dos campos conserva to y descarta from.
+func renameField(ctx context.Context, db *mongo.Database, collection, from, to string) error {
+	coll := db.Collection(collection)
+	if _, err := coll.UpdateMany(ctx,
+		bson.D{{Key: from, Value: bson.D{{Key: "$exists", Value: true}}}, {Key: to, Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "$rename", Value: bson.D{{Key: from, Value: to}}}},
+	); err != nil {
+		return err
+	}
+	_, err := coll.UpdateMany(ctx,
+		bson.D{{Key: from, Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.D{{Key: "$unset", Value: bson.D{{Key: from, Value: ""}}}},
+	)
+	return err
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database, name string, models ...mongo.IndexModel) error {
+	_, err := db.Collection(name).Indexes().CreateMany(ctx, models)
+	return err
+}
+
+func dropIndexes(ctx context.Context, db *mongo.Database, name string, indexNames ...string) error {
+	for _, indexName := range indexNames {
+		err := db.Collection(name).Indexes().DropOne(ctx, indexName)
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && (cmdErr.Name == "IndexNotFound" || cmdErr.Name == "NamespaceNotFound") {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
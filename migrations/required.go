@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// IndexRef identifica un índice por colección y nombre.
+type IndexRef struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+}
+
+// RequiredIndexes son los índices sin los cuales la API no funciona bien:
+// los únicos que evitan duplicados y el de texto que usa la búsqueda.
+var RequiredIndexes = []IndexRef{
+	{database.UsersCollection, UsersEmailIndex},
+	{database.UsersCollection, UsersUserIDIndex},
+	{database.MoviesCollection, MoviesImdbIDIndex},
+	{database.MoviesCollection, MoviesTextIndex},
+	{database.GenresCollection, GenresIDIndex},
+	{database.GenresCollection, GenresNameIndex},
+	{database.RatingsCollection, RatingsUserIndex},
+	{database.ReviewsCollection, ReviewsUserIndex},
+	{database.ListsCollection, ListsSlugIndex},
+	{database.ListsCollection, ListsWatchIndex},
+	{database.WatchHistoryCollection, WatchUserIndex},
+	{database.NeighboursCollection, NeighboursIndex},
+}
+
+// MissingIndexes devuelve los índices de RequiredIndexes que no existen.
+func MissingIndexes(ctx context.Context, db *mongo.Database) ([]IndexRef, error) {
+	existing := make(map[string]map[string]bool)
+	var missing []IndexRef
+	for _, ref := range RequiredIndexes {
+		names, ok := existing[ref.Collection]
+		if !ok {
+			specs, err := db.Collection(ref.Collection).Indexes().ListSpecifications(ctx)
+			if err != nil {
+				return nil, err
+			}
+			names = make(map[string]bool, len(specs))
+			for _, spec := range specs {
+				names[spec.Name] = true
+			}
+			existing[ref.Collection] = names
+		}
+		if !names[ref.Name] {
+			missing = append(missing, ref)
+		}
+	}
+	return missing, nil
+}
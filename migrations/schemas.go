@@ -0,0 +1,100 @@
+package migrations
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// Esquemas JSON usados por los validadores de las colecciones.
+// Reflejan las etiquetas validate de los modelos.
+
+var genreSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"genre_id", "genre_name"},
+	"properties": bson.M{
+		"genre_id":   bson.M{"bsonType": bson.A{"int", "long"}},
+		"genre_name": bson.M{"bsonType": "string", "minLength": 2, "maxLength": 100},
+	},
+}
+
+var movieSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"imdb_id", "title", "poster_path", "youtube_id", "genre"},
+	"properties": bson.M{
+		"imdb_id":      bson.M{"bsonType": "string", "minLength": 1},
+		"title":        bson.M{"bsonType": "string", "minLength": 2, "maxLength": 500},
+		"poster_path":  bson.M{"bsonType": "string"},
+		"youtube_id":   bson.M{"bsonType": "string"},
+		"genre":        bson.M{"bsonType": "array", "items": genreSchema},
+		"admin_review": bson.M{"bsonType": "string"},
+		"description":  bson.M{"bsonType": "string"},
+		"watch_url":    bson.M{"bsonType": "string"},
+	},
+}
+
+var userSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"user_id", "first_name", "last_name", "email", "password"},
+	"properties": bson.M{
+		"user_id":          bson.M{"bsonType": "string", "minLength": 1},
+		"first_name":       bson.M{"bsonType": "string", "minLength": 2, "maxLength": 100},
+		"last_name":        bson.M{"bsonType": "string", "minLength": 2, "maxLength": 100},
+		"email":            bson.M{"bsonType": "string", "pattern": "^[^@\\s]+@[^@\\s]+$"},
+		"password":         bson.M{"bsonType": "string", "minLength": 6},
+		"role":             bson.M{"enum": bson.A{"ADMIN", "USER"}},
+		"favourite_genres": bson.M{"bsonType": "array", "items": genreSchema},
+	},
+}
+
+var ratingSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"user_id", "imdb_id", "rating"},
+	"properties": bson.M{
+		"user_id": bson.M{"bsonType": "string", "minLength": 1},
+		"imdb_id": bson.M{"bsonType": "string", "minLength": 1},
+		"rating":  bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 1, "maximum": 10},
+	},
+}
+
+var reviewSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"user_id", "imdb_id", "text", "status"},
+	"properties": bson.M{
+		"user_id": bson.M{"bsonType": "string", "minLength": 1},
+		"imdb_id": bson.M{"bsonType": "string", "minLength": 1},
+		"text":    bson.M{"bsonType": "string", "minLength": 10, "maxLength": 5000},
+		"status":  bson.M{"enum": bson.A{"pending", "approved", "rejected", "hidden"}},
+	},
+}
+
+var listSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"user_id", "name", "slug", "kind", "visibility", "items"},
+	"properties": bson.M{
+		"user_id":    bson.M{"bsonType": "string", "minLength": 1},
+		"name":       bson.M{"bsonType": "string", "minLength": 1, "maxLength": 100},
+		"slug":       bson.M{"bsonType": "string", "minLength": 1},
+		"kind":       bson.M{"enum": bson.A{"watchlist", "custom"}},
+		"visibility": bson.M{"enum": bson.A{"public", "private"}},
+		"items": bson.M{
+			"bsonType": "array",
+			"maxItems": 1000,
+			"items": bson.M{
+				"bsonType": "object",
+				"required": bson.A{"imdb_id"},
+				"properties": bson.M{
+					"imdb_id": bson.M{"bsonType": "string", "minLength": 1},
+				},
+			},
+		},
+	},
+}
+
+var watchSchema = bson.M{
+	"bsonType": "object",
+	"required": bson.A{"user_id", "imdb_id", "status", "position_seconds"},
+	"properties": bson.M{
+		"user_id":          bson.M{"bsonType": "string", "minLength": 1},
+		"imdb_id":          bson.M{"bsonType": "string", "minLength": 1},
+		"status":           bson.M{"enum": bson.A{"in_progress", "finished"}},
+		"position_seconds": bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+		"duration_seconds": bson.M{"bsonType": bson.A{"int", "long"}, "minimum": 0},
+	},
+}
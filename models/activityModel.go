@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// ActivityKind es el tipo de actividad que cuenta para la popularidad.
+type ActivityKind string
+
+const (
+	ActivityView      ActivityKind = "view"
+	ActivityRating    ActivityKind = "rating"
+	ActivityWatchlist ActivityKind = "watchlist"
+)
+
+// ActivityEvent es una acción de un usuario sobre una película. Los eventos
+// vencen solos a los pocos días; solo sirven para calcular la popularidad.
+type ActivityEvent struct {
+	// Vacío si el usuario no inició sesión
+	UserID    string       `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	ImdbID    string       `bson:"imdb_id" json:"imdb_id"`
+	Kind      ActivityKind `bson:"kind" json:"kind"`
+	CreatedAt time.Time    `bson:"created_at" json:"created_at"`
+}
+
+// Popularity son los puntajes de popularidad de una película en cada
+// ventana de tiempo. Los calcula el trabajo de tendencias.
+type Popularity struct {
+	Hourly    float64   `bson:"hourly" json:"hourly"`
+	Daily     float64   `bson:"daily" json:"daily"`
+	Weekly    float64   `bson:"weekly" json:"weekly"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// ActivityBucket cuenta los eventos de un tipo sobre una película en un
+// intervalo que empieza en Start.
+type ActivityBucket struct {
+	ImdbID string       `bson:"imdb_id"`
+	Kind   ActivityKind `bson:"kind"`
+	Start  time.Time    `bson:"start"`
+	Count  int          `bson:"count"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/textdiff"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// AdminReviewVersion es una versión de la reseña del administrador de una
+// película. Las versiones se numeran desde 1 por película.
+type AdminReviewVersion struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	ImdbID     string        `bson:"imdb_id" json:"imdb_id"`
+	Version    int           `bson:"version" json:"version"`
+	Text       string        `bson:"text" json:"text"`
+	AuthorID   string        `bson:"author_id,omitempty" json:"author_id,omitempty"`
+	AuthorName string        `bson:"author_name,omitempty" json:"author_name,omitempty"`
+	// Cambios respecto de la versión anterior, palabra por palabra
+	Diff []textdiff.Op `bson:"diff" json:"diff"`
+	// Versión restaurada, si el cambio fue una reversión
+	RevertedFrom int       `bson:"reverted_from,omitempty" json:"reverted_from,omitempty"`
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenreCatalog son los géneros existentes indexados por genre_id.
+type GenreCatalog map[int]Genre
+
+// UnknownGenresError indica géneros que no existen en el catálogo.
+type UnknownGenresError struct {
+	IDs []int
+}
+
+func (e *UnknownGenresError) Error() string {
+	ids := make([]string, len(e.IDs))
+	for i, id := range e.IDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return fmt.Sprintf("géneros inexistentes: %s", strings.Join(ids, ", "))
+}
+
+// Resolve verifica que todos los géneros existan y devuelve sus copias con el
+// nombre oficial (sin los nombres por idioma), sin repetidos. Si alguno no existe devuelve *UnknownGenresError.
+func (c GenreCatalog) Resolve(genres []Genre) ([]Genre, error) {
+	resolved := make([]Genre, 0, len(genres))
+	seen := map[int]bool{}
+	var unknown []int
+
+	for _, genre := range genres {
+		if seen[genre.GenreID] {
+			continue
+		}
+		seen[genre.GenreID] = true
+
+		official, ok := c[genre.GenreID]
+		if !ok {
+			unknown = append(unknown, genre.GenreID)
+			continue
+		}
+		official.Names = nil
+		resolved = append(resolved, official)
+	}
+
+	if len(unknown) > 0 {
+		sort.Ints(unknown)
+		return nil, &UnknownGenresError{IDs: unknown}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ListKind distingue la lista de pendientes de las listas creadas por el usuario.
+type ListKind string
+
+const (
+	// Lista de pendientes: hay una por usuario y se crea sola
+	ListWatchlist ListKind = "watchlist"
+	ListCustom    ListKind = "custom"
+)
+
+// ListVisibility indica si una lista se puede ver con su slug sin iniciar sesión.
+type ListVisibility string
+
+const (
+	ListPrivate ListVisibility = "private"
+	ListPublic  ListVisibility = "public"
+)
+
+// MovieList es una lista de películas de un usuario, en el orden que él elige.
+type MovieList struct {
+	ID         bson.ObjectID  `bson:"_id,omitempty" json:"list_id"`
+	UserID     string         `bson:"user_id" json:"user_id"`
+	Name       string         `bson:"name" json:"name"`
+	Slug       string         `bson:"slug" json:"slug"`
+	Kind       ListKind       `bson:"kind" json:"kind"`
+	Visibility ListVisibility `bson:"visibility" json:"visibility"`
+	Items      []ListItem     `bson:"items" json:"items"`
+	CreatedAt  time.Time      `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+type ListItem struct {
+	ImdbID  string    `bson:"imdb_id" json:"imdb_id"`
+	AddedAt time.Time `bson:"added_at" json:"added_at"`
+}
+
+// ListMembership indica en qué listas del usuario está una película.
+type ListMembership struct {
+	InWatchlist bool     `json:"in_watchlist"`
+	ListIDs     []string `json:"list_ids"`
+}
@@ -0,0 +1,41 @@
+package models
+
+// Reglas de idioma:
+//   - Género: el nombre en el idioma pedido; si no existe, genre_name.
+//   - Película: título y descripción de translations[idioma] si no están
+//     vacíos; si no, los campos title y description (en español).
+//   - Los géneros de una película toman el nombre del catálogo de géneros.
+// Las versiones localizadas no incluyen names ni translations.
+
+// Localized devuelve el género con el nombre en el idioma indicado.
+func (g Genre) Localized(locale string) Genre {
+	if name := g.Names[locale]; name != "" {
+		g.GenreName = name
+	}
+	g.Names = nil
+	return g
+}
+
+// Localized devuelve la película con título, descripción y géneros en el
+// idioma indicado.
+func (m Movie) Localized(locale string, genres GenreCatalog) Movie {
+	if translation, ok := m.Translations[locale]; ok {
+		if translation.Title != "" {
+			m.Title = translation.Title
+		}
+		if translation.Description != "" {
+			m.Description = translation.Description
+		}
+	}
+	m.Translations = nil
+
+	localized := make([]Genre, len(m.Genre))
+	for i, genre := range m.Genre {
+		if official, ok := genres[genre.GenreID]; ok {
+			genre = official
+		}
+		localized[i] = genre.Localized(locale)
+	}
+	m.Genre = localized
+	return m
+}
@@ -7,6 +7,14 @@ import (
 type Genre struct {
 	GenreID   int    `bson:"genre_id" json:"genre_id" validate:"required"`
 	GenreName string `bson:"genre_name" json:"genre_name" validate:"required,min=2,max=100"`
+	// Nombres por idioma ("es": "Comedia", "en": "Comedy"). Solo se guarda en
+	// la colección genres; las copias en películas y usuarios no lo llevan.
+	Names map[string]string `bson:"names,omitempty" json:"names,omitempty" validate:"omitempty,dive,keys,oneof=es en,endkeys,min=2,max=100"`
+}
+
+type Ranking struct {
+	RankingValue int    `bson:"ranking_value" json:"ranking_value"`
+	RankingName  string `bson:"ranking_name" json:"ranking_name"`
 }
 
 type Movie struct {
@@ -19,4 +27,35 @@ type Movie struct {
 	AdminReview string        `bson:"admin_review" json:"admin_review"`
 	Description string        `bson:"description" json:"description"`
 	WatchURL    string        `bson:"watch_url" json:"watch_url"`
+	Ranking     Ranking       `bson:"ranking" json:"ranking"`
+	ReleaseYear int           `bson:"release_year,omitempty" json:"release_year,omitempty" validate:"omitempty,min=1888,max=2100"`
+	// Título y descripción en otros idiomas, por código de idioma
+	Translations map[string]MovieTranslation `bson:"translations,omitempty" json:"translations,omitempty" validate:"omitempty,dive,keys,oneof=es en,endkeys"`
+	// Puntaje de los usuarios. Lo mantiene el repositorio de calificaciones;
+	// las altas e importaciones de películas lo ignoran.
+	UserScore *UserScore `bson:"user_score,omitempty" json:"user_score,omitempty"`
+	// Popularidad reciente. La mantiene el trabajo de tendencias; las altas e
+	// importaciones de películas la ignoran.
+	Popularity *Popularity `bson:"popularity,omitempty" json:"popularity,omitempty"`
+	// Listas del usuario que pide la película. Solo se completa en las
+	// respuestas a usuarios con sesión; no se guarda.
+	Membership *ListMembership `bson:"-" json:"membership,omitempty"`
+}
+
+type MovieTranslation struct {
+	Title       string `bson:"title,omitempty" json:"title,omitempty" validate:"omitempty,min=2,max=500"`
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// ScoredMovie es una película con su relevancia en una búsqueda.
+type ScoredMovie struct {
+	Movie `bson:",inline"`
+	Score float64 `bson:"score,omitempty" json:"score"`
+}
+
+// MovieSummary son los datos mínimos para mostrar una película en una lista.
+type MovieSummary struct {
+	ImdbID     string `bson:"imdb_id" json:"imdb_id"`
+	Title      string `bson:"title" json:"title"`
+	PosterPath string `bson:"poster_path" json:"poster_path"`
 }
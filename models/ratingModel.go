@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Rating es la calificación de 1 a 10 de un usuario a una película. Hay una
+// sola por usuario y película.
+type Rating struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	UserID    string        `bson:"user_id" json:"user_id"`
+	ImdbID    string        `bson:"imdb_id" json:"imdb_id"`
+	Value     int           `bson:"rating" json:"rating" validate:"required,min=1,max=10"`
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at" json:"updated_at"`
+}
+
+// UserScore es el promedio de las calificaciones de una película.
+type UserScore struct {
+	Average float64 `bson:"average" json:"average"`
+	Count   int     `bson:"count" json:"count"`
+	// Suma de las calificaciones, para actualizar el promedio sin releerlas
+	Sum int `bson:"sum" json:"-"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Interaction es cuánto le interesó una película a un usuario, de 0 a 1,
+// según sus calificaciones y lo que vio. Con peso 0 la película cuenta como
+// vista pero no como preferencia.
+type Interaction struct {
+	UserID string
+	ImdbID string
+	Weight float64
+}
+
+// Neighbour es una película parecida según los usuarios que vieron ambas.
+type Neighbour struct {
+	ImdbID string  `bson:"imdb_id" json:"imdb_id"`
+	Score  float64 `bson:"score" json:"score"`
+}
+
+// MovieNeighbours son las películas vecinas precalculadas de una película.
+type MovieNeighbours struct {
+	ImdbID     string      `bson:"imdb_id"`
+	Neighbours []Neighbour `bson:"neighbours"`
+	BuiltAt    time.Time   `bson:"built_at"`
+}
+
+// RecommendationSource indica de dónde sale una recomendación.
+type RecommendationSource string
+
+const (
+	// Parecida a películas que el usuario calificó bien o vio
+	SourceCollaborative RecommendationSource = "collaborative"
+	// De los géneros favoritos del usuario
+	SourceGenres RecommendationSource = "genres"
+)
+
+// Recommendation es una película recomendada a un usuario.
+type Recommendation struct {
+	MovieSummary
+	Score  float64              `json:"score"`
+	Source RecommendationSource `json:"source"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ReviewStatus es el estado de moderación de una reseña.
+type ReviewStatus string
+
+const (
+	// Marcada por la revisión automática, espera a un administrador
+	ReviewPending ReviewStatus = "pending"
+	// Visible para todos
+	ReviewApproved ReviewStatus = "approved"
+	// Rechazada por un administrador
+	ReviewRejected ReviewStatus = "rejected"
+	// Aprobada antes, ocultada después por un administrador
+	ReviewHidden ReviewStatus = "hidden"
+)
+
+// Review es la reseña escrita por un usuario. Hay una sola por usuario y película.
+type Review struct {
+	ID       bson.ObjectID `bson:"_id,omitempty" json:"review_id"`
+	UserID   string        `bson:"user_id" json:"user_id"`
+	UserName string        `bson:"user_name" json:"user_name"`
+	ImdbID   string        `bson:"imdb_id" json:"imdb_id"`
+	Text     string        `bson:"text" json:"text" validate:"required,min=10,max=5000"`
+	Status   ReviewStatus  `bson:"status" json:"status"`
+	// Motivos de la revisión automática (profanity, links, repetition, shouting)
+	Flags []string `bson:"flags,omitempty" json:"flags,omitempty"`
+	// Motivo que deja el administrador al moderar
+	ModerationNote string     `bson:"moderation_note,omitempty" json:"moderation_note,omitempty"`
+	ModeratedBy    string     `bson:"moderated_by,omitempty" json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// PlaybackEvent es un evento que envía el reproductor.
+type PlaybackEvent string
+
+const (
+	PlaybackStarted  PlaybackEvent = "started"
+	PlaybackProgress PlaybackEvent = "progress"
+	PlaybackFinished PlaybackEvent = "finished"
+)
+
+// WatchStatus es el estado de una película en el historial del usuario.
+type WatchStatus string
+
+const (
+	WatchInProgress WatchStatus = "in_progress"
+	WatchFinished   WatchStatus = "finished"
+)
+
+// WatchEntry es lo que un usuario vio de una película. Hay una por usuario y
+// película; cada evento de reproducción la actualiza.
+type WatchEntry struct {
+	UserID string      `bson:"user_id" json:"-"`
+	ImdbID string      `bson:"imdb_id" json:"imdb_id"`
+	Status WatchStatus `bson:"status" json:"status"`
+	// Segundos vistos y duración total que informa el reproductor
+	PositionSeconds int `bson:"position_seconds" json:"position_seconds"`
+	DurationSeconds int `bson:"duration_seconds,omitempty" json:"duration_seconds,omitempty"`
+	// Veces que se empezó a ver y veces que se terminó
+	PlayCount     int        `bson:"play_count" json:"play_count"`
+	FinishCount   int        `bson:"finish_count" json:"finish_count"`
+	StartedAt     time.Time  `bson:"started_at" json:"started_at"`
+	LastWatchedAt time.Time  `bson:"last_watched_at" json:"last_watched_at"`
+	FinishedAt    *time.Time `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+	// Datos de la película para mostrar el historial
+	Movie *MovieSummary `bson:"movie,omitempty" json:"movie,omitempty"`
+}
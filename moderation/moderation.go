@@ -0,0 +1,122 @@
+// Package moderation revisa automáticamente el texto de las reseñas. No
+// rechaza nada: solo marca las reseñas sospechosas para que las vea un
+// administrador.
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+)
+
+// Motivos por los que se marca una reseña
+const (
+	FlagProfanity  = "profanity"
+	FlagLinks      = "links"
+	FlagRepetition = "repetition"
+	FlagShouting   = "shouting"
+)
+
+// Palabras ofensivas en español e inglés, ya normalizadas (sin tildes y en
+// minúsculas) para compararlas con utils.Tokens.
+var profanity = map[string]bool{
+	"mierda": true, "puta": true, "puto": true, "pendejo": true, "pendeja": true,
+	"boludo": true, "pelotudo": true, "forro": true, "carajo": true, "concha": true,
+	"verga": true, "culero": true, "cabron": true, "gilipollas": true, "idiota": true,
+	"imbecil": true, "estupido": true, "estupida": true, "hdp": true,
+	"fuck": true, "fucking": true, "shit": true, "bitch": true, "asshole": true,
+	"cunt": true, "dick": true, "bastard": true,
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+const (
+	// Enlaces permitidos antes de considerar la reseña spam
+	maxLinks = 1
+	// Veces que puede repetirse una misma letra seguida ("buenaaaaaaa")
+	maxRepeatedChars = 6
+	// Proporción de veces que puede aparecer una misma palabra
+	maxWordShare = 0.4
+	// Palabras mínimas para evaluar palabras repetidas
+	minWordsToCheck = 5
+	// Letras mínimas para evaluar mayúsculas
+	minLettersToCheck = 20
+	// Proporción de mayúsculas a partir de la cual el texto "grita"
+	maxUpperShare = 0.7
+)
+
+// Check devuelve los motivos por los que el texto debería moderarse, o nil
+// si parece correcto.
+func Check(text string) []string {
+	var flags []string
+
+	words := utils.Tokens(text)
+	for _, word := range words {
+		if profanity[word] {
+			flags = append(flags, FlagProfanity)
+			break
+		}
+	}
+
+	if len(linkPattern.FindAllString(text, -1)) > maxLinks {
+		flags = append(flags, FlagLinks)
+	}
+
+	if hasRepeatedChars(text) || hasDominantWord(words) {
+		flags = append(flags, FlagRepetition)
+	}
+
+	if isShouting(text) {
+		flags = append(flags, FlagShouting)
+	}
+
+	return flags
+}
+
+func hasRepeatedChars(text string) bool {
+	var last rune
+	run := 0
+	for _, char := range strings.ToLower(text) {
+		if char == last && !unicode.IsSpace(char) {
+			run++
+			if run > maxRepeatedChars {
+				return true
+			}
+			continue
+		}
+		last, run = char, 1
+	}
+	return false
+}
+
+func hasDominantWord(words []string) bool {
+	if len(words) < minWordsToCheck {
+		return false
+	}
+	counts := map[string]int{}
+	for _, word := range words {
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		counts[word]++
+		if float64(counts[word]) > maxWordShare*float64(len(words)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isShouting(text string) bool {
+	letters, upper := 0, 0
+	for _, char := range text {
+		if unicode.IsLetter(char) {
+			letters++
+			if unicode.IsUpper(char) {
+				upper++
+			}
+		}
+	}
+	return letters >= minLettersToCheck && float64(upper) > maxUpperShare*float64(letters)
+}
@@ -0,0 +1,66 @@
+package moderation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"correcta", "Una película excelente, con muy buenas actuaciones.", nil},
+
+		{"grosería", "Qué película de mierda", []string{FlagProfanity}},
+		{"grosería con tilde y mayúsculas", "Un director IMBÉCIL", []string{FlagProfanity}},
+		{"grosería en inglés", "What a shit movie", []string{FlagProfanity}},
+		// Solo palabras completas: "computadora" contiene "puta"
+		{"palabra que contiene una grosería", "La computadora del protagonista", nil},
+
+		{"un enlace", "Más información en https://example.com/pelicula", nil},
+		{"dos enlaces", "Mirala en https://a.example y en http://b.example", []string{FlagLinks}},
+		{"enlaces sin esquema", "www.a.example o www.b.example", []string{FlagLinks}},
+
+		{"seis letras repetidas", "Buenaaaaaa película", nil},
+		{"siete letras repetidas", "Buenaaaaaaa película", []string{FlagRepetition}},
+		{"signos repetidos", "Excelente!!!!!!!", []string{FlagRepetition}},
+		{"espacios repetidos", "Muy        buena", nil},
+		// Más del 40 % de las palabras son la misma
+		{"palabra dominante", "mala mala mala pero entretenida", []string{FlagRepetition}},
+		{"palabra repetida al 40 %", "mala mala aunque bastante entretenida", nil},
+		{"menos de cinco palabras", "mala mala mala", nil},
+		{"palabras cortas", "la la la la película buena", nil},
+
+		{"gritos", "ESTA PELÍCULA ES MUY BUENA", []string{FlagShouting}},
+		{"gritos cortos", "MUY BUENA", nil},
+		// 14 de 20 letras en mayúscula: exactamente el 70 %
+		{"mayúsculas al 70 %", "ABCDEFGHIJKLMN opqrst", nil},
+		{"mayúsculas sobre el 70 %", "ABCDEFGHIJKLMNO opqrst", []string{FlagShouting}},
+
+		{
+			"varios motivos",
+			"PELÍCULA DE MIERDA, NO LA VEAN NUNCA JAMÁS EN SUS VIDAS: http://a.io http://b.io",
+			[]string{FlagProfanity, FlagLinks, FlagShouting},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Check(tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("Check(%q) = %q, se esperaba %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// Las palabras de la lista están normalizadas como las de utils.Tokens: si no,
+// nunca coincidirían.
+func TestProfanityIsNormalized(t *testing.T) {
+	for word := range profanity {
+		if word != strings.ToLower(word) || strings.ContainsAny(word, "áéíóúüñ") {
+			t.Errorf("%q no está normalizada", word)
+		}
+	}
+}
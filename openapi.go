@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/app"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/openapi"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// runOpenAPI imprime el documento OpenAPI. Con --check no lo imprime y
+// falla si hay rutas del router sin documentar o documentadas de más. No
+// necesita la configuración del entorno.
+func runOpenAPI(args []string) error {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	check := flags.Bool("check", false, "solo comprobar que todas las rutas estén documentadas")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !*check {
+		out := json.NewEncoder(os.Stdout)
+		out.SetIndent("", "  ")
+		return out.Encode(openapi.Build(app.APIDocs()))
+	}
+
+	// El router se arma sin consultar la base: el cliente no llega a conectarse
+	client, err := mongo.Connect()
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	// CORS exige al menos un origen; el resto de la configuración no afecta a las rutas
+	cfg := &config.Config{AllowedOrigins: []string{"http://localhost"}}
+	gin.SetMode(gin.ReleaseMode)
+	application := app.New(cfg, client.Database("peliculapp"), nil)
+	application.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	undocumented, unknown := app.CheckDocs(application.Router())
+	if len(undocumented) == 0 && len(unknown) == 0 {
+		fmt.Println("Todas las rutas están documentadas")
+		return nil
+	}
+	if len(undocumented) > 0 {
+		fmt.Printf("Rutas sin documentar en controllers.APIDocs:\n  %s\n", strings.Join(undocumented, "\n  "))
+	}
+	if len(unknown) > 0 {
+		fmt.Printf("Rutas documentadas que el router no registra:\n  %s\n", strings.Join(unknown, "\n  "))
+	}
+	return fmt.Errorf("la documentación OpenAPI no coincide con las rutas")
+}
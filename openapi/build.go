@@ -0,0 +1,329 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/apierror"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth es la autenticación que exige una ruta.
+type Auth int
+
+const (
+	// Public no usa la sesión
+	Public Auth = iota
+	// Optional reconoce la sesión si la hay, sin exigirla
+	Optional
+	// User exige la cookie access_token
+	User
+	// Admin exige la cookie access_token de un usuario ADMIN
+	Admin
+	// MetricsToken exige "Authorization: Bearer <METRICS_TOKEN>" si está configurado
+	MetricsToken
+)
+
+// Nombres de los esquemas de seguridad en components.securitySchemes
+const (
+	cookieScheme  = "cookieAuth"
+	metricsScheme = "metricsToken"
+)
+
+// Route describe una ruta registrada en el router.
+type Route struct {
+	Method string
+	// Ruta con la sintaxis de gin: /movie/:imdb_id
+	Path        string
+	ID          string
+	Tag         string
+	Summary     string
+	Description string
+	Auth        Auth
+	// Respeta ?lang= y Accept-Language
+	Localized bool
+	// Parámetros de query y de ruta; los de ruta no descritos se agregan como texto
+	Params []Parameter
+	// Valor del tipo del cuerpo JSON, nil si no lleva
+	Body any
+	// Cuerpos que no son JSON, por tipo de contenido
+	BodyContent map[string]*Schema
+	// Estado de la respuesta exitosa, 200 si es cero
+	Status int
+	// Valor del tipo de la respuesta JSON, nil si no tiene cuerpo o no es JSON
+	Response any
+	// Respuestas exitosas que no son JSON, por tipo de contenido
+	ResponseContent map[string]*Schema
+	// Otras respuestas con cuerpo JSON, por estado
+	MoreResponses map[int]any
+	Deprecated    bool
+}
+
+// Spec es todo lo necesario para armar el documento.
+type Spec struct {
+	Info   Info
+	Tags   []Tag
+	Routes []Route
+	Enums  []Enum
+	Names  []Name
+}
+
+// Build arma el documento OpenAPI de la especificación.
+func Build(spec Spec) *Document {
+	g := NewGenerator(spec.Names, spec.Enums)
+	errorContent := jsonContent(g.Schema(reflect.TypeFor[apierror.Response]()))
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    spec.Info,
+		Tags:    spec.Tags,
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			Responses: map[string]Response{
+				"BadRequest":   {Description: "Parámetros o cuerpo inválidos", Content: errorContent},
+				"Unauthorized": {Description: "Falta la sesión o el token no es válido", Content: errorContent},
+				"Forbidden":    {Description: "El usuario no tiene permiso", Content: errorContent},
+				"NotFound":     {Description: "No existe el recurso", Content: errorContent},
+				"Error":        {Description: "Error; el código indica el motivo", Content: errorContent},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				cookieScheme: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        "access_token",
+					Description: "Token de acceso que deja el inicio de sesión en una cookie HttpOnly",
+				},
+				metricsScheme: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "METRICS_TOKEN, si está configurado",
+				},
+			},
+		},
+	}
+
+	for _, route := range spec.Routes {
+		path := Path(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation(g, route)
+	}
+	doc.Components.Schemas = g.Schemas()
+	return doc
+}
+
+func operation(g *Generator, route Route) Operation {
+	op := Operation{
+		OperationID: route.ID,
+		Summary:     route.Summary,
+		Description: route.Description,
+		Parameters:  parameters(route),
+		Responses:   make(map[string]Response),
+		Deprecated:  route.Deprecated,
+	}
+	if op.OperationID == "" {
+		op.OperationID = operationID(route.Method, route.Path)
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	switch route.Auth {
+	case Optional:
+		op.Security = []SecurityRequirement{{}, {cookieScheme: {}}}
+	case User, Admin:
+		op.Security = []SecurityRequirement{{cookieScheme: {}}}
+	case MetricsToken:
+		op.Security = []SecurityRequirement{{}, {metricsScheme: {}}}
+	}
+
+	if route.Body != nil || route.BodyContent != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: make(map[string]MediaType)}
+		if route.Body != nil {
+			op.RequestBody.Content["application/json"] = MediaType{Schema: g.Schema(reflect.TypeOf(route.Body))}
+		}
+		for contentType, schema := range route.BodyContent {
+			op.RequestBody.Content[contentType] = MediaType{Schema: schema}
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		success.Content = jsonContent(g.Schema(reflect.TypeOf(route.Response)))
+	}
+	for contentType, schema := range route.ResponseContent {
+		if success.Content == nil {
+			success.Content = make(map[string]MediaType)
+		}
+		success.Content[contentType] = MediaType{Schema: schema}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	for status, body := range route.MoreResponses {
+		op.Responses[strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content:     jsonContent(g.Schema(reflect.TypeOf(body))),
+		}
+	}
+
+	// Errores comunes, con el sobre de apierror
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses["400"] = errorRef("BadRequest")
+	}
+	if route.Auth == User || route.Auth == Admin || route.Auth == MetricsToken {
+		op.Responses["401"] = errorRef("Unauthorized")
+	}
+	if route.Auth == Admin {
+		op.Responses["403"] = errorRef("Forbidden")
+	}
+	if len(pathParams(route.Path)) > 0 {
+		op.Responses["404"] = errorRef("NotFound")
+	}
+	op.Responses["default"] = errorRef("Error")
+	return op
+}
+
+// parameters completa los parámetros de ruta que no se describieron y agrega
+// los de idioma en las rutas localizadas.
+func parameters(route Route) []Parameter {
+	params := slices.Clone(route.Params)
+	for _, name := range pathParams(route.Path) {
+		described := slices.ContainsFunc(params, func(p Parameter) bool { return p.In == "path" && p.Name == name })
+		if !described {
+			params = append(params, PathParam(name, "", String()))
+		}
+	}
+	if route.Localized {
+		params = append(params,
+			QueryParam("lang", "Idioma de la respuesta; tiene prioridad sobre Accept-Language", OneOf("", i18n.Supported...)),
+			Parameter{Name: "Accept-Language", In: "header", Description: "Idioma preferido (es por defecto)", Schema: String()},
+		)
+	}
+	return params
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+func errorRef(name string) Response {
+	return Response{Ref: "#/components/responses/" + name}
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Path convierte una ruta de gin (/movie/:imdb_id) a la sintaxis de OpenAPI (/movie/{imdb_id}).
+func Path(ginPath string) string {
+	return ginParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+func pathParams(ginPath string) []string {
+	var names []string
+	for _, match := range ginParam.FindAllStringSubmatch(ginPath, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// operationID arma un identificador a partir del método y la ruta:
+// GET /movie/:imdb_id/similar → getMovieImdbIdSimilar.
+func operationID(method, ginPath string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range strings.FieldsFunc(ginPath, func(r rune) bool {
+		return r == '/' || r == ':' || r == '*' || r == '_' || r == '-' || r == '.'
+	}) {
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
+}
+
+// QueryParam es un parámetro de query opcional.
+func QueryParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// PathParam es un parámetro de ruta.
+func PathParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+// MaxLength limita el largo de un texto.
+func MaxLength(schema *Schema, n int) *Schema {
+	schema.MaxLength = &n
+	return schema
+}
+
+func Bool() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+// Int es un entero entre min y max con valor por defecto def. max 0 es sin máximo.
+func Int(min, max, def int) *Schema {
+	low := float64(min)
+	schema := &Schema{Type: "integer", Minimum: &low}
+	if max > 0 {
+		high := float64(max)
+		schema.Maximum = &high
+	}
+	if def != 0 {
+		schema.Default = def
+	}
+	return schema
+}
+
+// OneOf es un texto con uno de los valores indicados. def vacío es sin valor por defecto.
+func OneOf(def string, values ...string) *Schema {
+	schema := &Schema{Type: "string"}
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, value)
+	}
+	if def != "" {
+		schema.Default = def
+	}
+	return schema
+}
+
+// Binary describe un archivo o texto sin estructura.
+func Binary() *Schema {
+	return &Schema{Type: "string", Format: "binary"}
+}
+
+// Compare cruza las rutas registradas con las documentadas. Devuelve las
+// registradas sin documentar y las documentadas que no existen, como
+// "GET /ruta", ordenadas.
+func Compare(registered gin.RoutesInfo, documented []Route) (undocumented, unknown []string) {
+	docs := make(map[string]bool, len(documented))
+	for _, route := range documented {
+		docs[strings.ToUpper(route.Method)+" "+route.Path] = true
+	}
+	seen := make(map[string]bool, len(registered))
+	for _, route := range registered {
+		k := route.Method + " " + route.Path
+		seen[k] = true
+		if !docs[k] {
+			undocumented = append(undocumented, k)
+		}
+	}
+	for k := range docs {
+		if !seen[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	slices.Sort(undocumented)
+	slices.Sort(unknown)
+	return undocumented, unknown
+}
@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Versión de Swagger UI que carga la página de documentación
+const swaggerUIVersion = "5.17.14"
+
+// Handler sirve el documento en JSON. Se serializa una sola vez.
+func Handler(doc *Document) gin.HandlerFunc {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		panic(fmt.Sprintf("openapi: no se pudo serializar el documento: %v", err))
+	}
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", body)
+	}
+}
+
+// UIHandler sirve Swagger UI con el documento de specURL. Los archivos de
+// Swagger UI se cargan desde jsDelivr.
+func UIHandler(title, specURL string) gin.HandlerFunc {
+	page := fmt.Sprintf(uiPage, html.EscapeString(title), swaggerUIVersion, swaggerUIVersion, specURL)
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	}
+}
+
+const uiPage = `<!doctype html>
+<html lang="es">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>%s</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui", withCredentials: true });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,308 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	objectIDType = reflect.TypeFor[bson.ObjectID]()
+)
+
+// Enum son los valores posibles de un tipo con nombre. Salen de sus
+// constantes, que no se pueden leer por reflexión.
+type Enum struct {
+	Type   reflect.Type
+	Values []any
+}
+
+// EnumOf declara los valores posibles del tipo T.
+func EnumOf[T any](values ...T) Enum {
+	enum := Enum{Type: reflect.TypeFor[T](), Values: make([]any, len(values))}
+	for i, value := range values {
+		enum.Values[i] = value
+	}
+	return enum
+}
+
+// Name es el nombre con el que se publica un tipo en components.schemas,
+// para los que tienen nombres genéricos como Response.
+type Name struct {
+	Type reflect.Type
+	Name string
+}
+
+// NameOf publica el tipo T con el nombre indicado.
+func NameOf[T any](name string) Name {
+	return Name{Type: reflect.TypeFor[T](), Name: name}
+}
+
+// Generator convierte tipos de Go en esquemas. Los structs con nombre se
+// guardan en components.schemas y se usan con $ref.
+type Generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+	enums   map[reflect.Type][]any
+	renamed map[reflect.Type]string
+}
+
+func NewGenerator(names []Name, enums []Enum) *Generator {
+	g := &Generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+		enums:   make(map[reflect.Type][]any, len(enums)),
+		renamed: make(map[reflect.Type]string, len(names)),
+	}
+	for _, enum := range enums {
+		g.enums[enum.Type] = enum.Values
+	}
+	for _, name := range names {
+		g.renamed[name.Type] = name.Name
+	}
+	return g
+}
+
+// Schemas devuelve los esquemas con nombre generados hasta ahora.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// Schema describe el tipo t tal como lo serializa encoding/json, con las
+// restricciones de las etiquetas validate de sus campos.
+func (g *Generator) Schema(t reflect.Type) *Schema {
+	t = deref(t)
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case objectIDType:
+		return &Schema{Type: "string", Pattern: "^[0-9a-f]{24}$"}
+	}
+	if values, ok := g.enums[t]; ok {
+		schema := basic(t)
+		schema.Enum = values
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.ref(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Schema(t.Elem())}
+	}
+	return basic(t)
+}
+
+func (g *Generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = g.uniqueName(t)
+		g.names[t] = name
+		// Se reserva el nombre antes de recorrer los campos por si el tipo es recursivo
+		g.schemas[name] = &Schema{}
+		g.schemas[name] = g.object(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// uniqueName usa el nombre declarado con NameOf o el del tipo con mayúscula
+// inicial; si otro paquete ya lo usa le antepone el nombre del paquete.
+func (g *Generator) uniqueName(t reflect.Type) string {
+	if name, ok := g.renamed[t]; ok {
+		return name
+	}
+	name := capitalize(t.Name())
+	if _, taken := g.schemas[name]; taken {
+		name = capitalize(path.Base(t.PkgPath())) + name
+	}
+	return name
+}
+
+func (g *Generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t)
+	return schema
+}
+
+// addFields agrega los campos de t. Los structs embebidos sin nombre JSON
+// aportan sus campos, como hace encoding/json.
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && deref(field.Type).Kind() == reflect.Struct {
+			g.addFields(schema, deref(field.Type))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := g.Schema(field.Type)
+		if constrain(property, field.Type, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// constrain traduce las reglas de validator al esquema y devuelve si el
+// campo es obligatorio. dive pasa a los elementos y keys/endkeys a las
+// claves de un mapa.
+func constrain(schema *Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	target, targetType := schema, deref(t)
+	// Mapa en el que entró el último dive, para keys y endkeys
+	var mapSchema *Schema
+	var mapType reflect.Type
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = required || target == schema
+		case "dive":
+			switch {
+			case target.Items != nil:
+				target, targetType = target.Items, deref(targetType.Elem())
+			case target.AdditionalProperties != nil:
+				mapSchema, mapType = target, targetType
+				target, targetType = target.AdditionalProperties, deref(targetType.Elem())
+			default:
+				return required
+			}
+		case "keys":
+			if mapSchema == nil {
+				return required
+			}
+			mapSchema.PropertyNames = basic(mapType.Key())
+			target, targetType = mapSchema.PropertyNames, mapType.Key()
+		case "endkeys":
+			if mapSchema == nil {
+				return required
+			}
+			target, targetType = mapSchema.AdditionalProperties, deref(mapType.Elem())
+		case "min", "max", "len":
+			bound(target, targetType, name, param)
+		case "oneof":
+			target.Enum = nil
+			for _, value := range strings.Fields(param) {
+				if n, err := strconv.Atoi(value); err == nil && isInteger(targetType) {
+					target.Enum = append(target.Enum, n)
+				} else {
+					target.Enum = append(target.Enum, value)
+				}
+			}
+		case "email":
+			target.Format = "email"
+		case "url":
+			target.Format = "uri"
+		}
+	}
+	return required
+}
+
+// bound aplica min, max o len según el tipo: largo para textos, valor para
+// números y cantidad para listas y mapas.
+func bound(schema *Schema, t reflect.Type, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := int(n)
+	low, high := rule != "max", rule != "min"
+
+	switch {
+	case t.Kind() == reflect.String:
+		if low {
+			schema.MinLength = &count
+		}
+		if high {
+			schema.MaxLength = &count
+		}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if low {
+			schema.MinItems = &count
+		}
+		if high {
+			schema.MaxItems = &count
+		}
+	case t.Kind() == reflect.Map:
+		if low {
+			schema.MinProperties = &count
+		}
+		if high {
+			schema.MaxProperties = &count
+		}
+	case isInteger(t) || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		if low {
+			schema.Minimum = &n
+		}
+		if high {
+			schema.Maximum = &n
+		}
+	}
+}
+
+func basic(t reflect.Type) *Schema {
+	switch {
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case isInteger(t):
+		return &Schema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	// interface{} y tipos sin equivalente: cualquier valor
+	return &Schema{}
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+)
+
+func runRatings(cfg *config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "recompute" {
+		return fmt.Errorf("subcomando desconocido: usar ratings recompute")
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	ratings := repositories.NewRatingRepository(client.Database(cfg.DatabaseName))
+	if err := ratings.RecomputeScores(ctx); err != nil {
+		return err
+	}
+	fmt.Println("Puntajes recalculados desde las calificaciones")
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/recommend"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+)
+
+func runRecommend(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("falta el subcomando: recommend build|evaluate")
+	}
+
+	flags := flag.NewFlagSet("recommend "+args[0], flag.ContinueOnError)
+	k := flags.Int("k", 10, "largo de la lista evaluada")
+	holdout := flags.Float64("holdout", 0.2, "parte de las interacciones positivas que se ocultan")
+	minInteractions := flags.Int("min-interactions", 5, "interacciones positivas mínimas para evaluar a un usuario")
+	seed := flags.Uint64("seed", 1, "semilla para elegir las interacciones ocultas")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *k < 1 {
+		return fmt.Errorf("-k debe ser mayor que cero")
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	db := client.Database(cfg.DatabaseName)
+	recommendations := repositories.NewRecommendationRepository(db)
+
+	interactions, err := recommendations.Interactions(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "build":
+		builtAt := time.Now()
+		neighbours := recommend.BuildNeighbours(interactions)
+		if err := recommendations.ReplaceNeighbours(ctx, neighbours, builtAt); err != nil {
+			return err
+		}
+		fmt.Printf("Vecinas calculadas para %d películas con %d interacciones\n", len(neighbours), len(interactions))
+		return nil
+
+	case "evaluate":
+		catalogue, err := repositories.NewMovieRepository(db).FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		favourites, err := repositories.NewUserRepository(db).FavouriteGenres(ctx)
+		if err != nil {
+			return err
+		}
+
+		report := recommend.Evaluate(interactions, catalogue, favourites, recommend.EvalOptions{
+			K:               *k,
+			Holdout:         *holdout,
+			MinInteractions: *minInteractions,
+			Seed:            *seed,
+		})
+		fmt.Printf("Usuarios evaluados: %d\n", report.Users)
+		fmt.Printf("precision@%d:        %.4f\n", report.K, report.Precision)
+		fmt.Printf("precision@%d géneros: %.4f\n", report.K, report.GenreBaseline)
+		return nil
+
+	default:
+		return fmt.Errorf("subcomando desconocido: recommend %s", args[0])
+	}
+}
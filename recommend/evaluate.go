@@ -0,0 +1,125 @@
+package recommend
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// EvalOptions configura la evaluación fuera de línea.
+type EvalOptions struct {
+	// Largo de la lista recomendada que se evalúa
+	K int
+	// Parte de las interacciones positivas de cada usuario que se ocultan
+	Holdout float64
+	// Usuarios con menos interacciones positivas no se evalúan
+	MinInteractions int
+	// Semilla para elegir las interacciones ocultas; la misma semilla da el
+	// mismo resultado
+	Seed uint64
+}
+
+// EvalReport es el resultado de una evaluación.
+type EvalReport struct {
+	Users int
+	K     int
+	// Precisión@k media del modelo completo
+	Precision float64
+	// Precisión@k media recomendando solo por géneros favoritos, como referencia
+	GenreBaseline float64
+}
+
+// Evaluate mide la precisión@k: a cada usuario se le ocultan algunas
+// películas que le gustaron, se entrena con el resto y se cuenta cuántas de
+// las k recomendadas estaban entre las ocultas.
+func Evaluate(interactions []models.Interaction, catalogue []models.Movie, favourites map[string][]models.Genre, opts EvalOptions) EvalReport {
+	byUser := map[string][]models.Interaction{}
+	for _, interaction := range interactions {
+		byUser[interaction.UserID] = append(byUser[interaction.UserID], interaction)
+	}
+
+	// Orden fijo de usuarios para que la semilla dé siempre el mismo reparto
+	users := make([]string, 0, len(byUser))
+	for userID := range byUser {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	hidden := map[string]map[string]bool{}
+	train := make([]models.Interaction, 0, len(interactions))
+	for _, userID := range users {
+		var positives, rest []models.Interaction
+		for _, interaction := range byUser[userID] {
+			if interaction.Weight > 0 {
+				positives = append(positives, interaction)
+			} else {
+				rest = append(rest, interaction)
+			}
+		}
+		train = append(train, rest...)
+
+		if len(positives) < opts.MinInteractions || len(positives) < 2 {
+			train = append(train, positives...)
+			continue
+		}
+
+		sort.Slice(positives, func(i, j int) bool { return positives[i].ImdbID < positives[j].ImdbID })
+		rng.Shuffle(len(positives), func(i, j int) { positives[i], positives[j] = positives[j], positives[i] })
+		count := min(max(1, int(math.Ceil(opts.Holdout*float64(len(positives))))), len(positives)-1)
+
+		hidden[userID] = map[string]bool{}
+		for _, interaction := range positives[:count] {
+			hidden[userID][interaction.ImdbID] = true
+		}
+		train = append(train, positives[count:]...)
+	}
+
+	neighbours := BuildNeighbours(train)
+	trainByUser := map[string][]models.Interaction{}
+	for _, interaction := range train {
+		trainByUser[interaction.UserID] = append(trainByUser[interaction.UserID], interaction)
+	}
+
+	report := EvalReport{K: opts.K}
+	for _, userID := range users {
+		if hidden[userID] == nil {
+			continue
+		}
+		in := Input{
+			Interactions:    trainByUser[userID],
+			Neighbours:      neighbours,
+			Catalogue:       catalogue,
+			FavouriteGenres: favourites[userID],
+			Limit:           opts.K,
+		}
+		report.Users++
+		report.Precision += precision(Recommend(in), hidden[userID], opts.K)
+
+		in.Neighbours = nil
+		report.GenreBaseline += precision(Recommend(in), hidden[userID], opts.K)
+	}
+
+	if report.Users > 0 {
+		report.Precision /= float64(report.Users)
+		report.GenreBaseline /= float64(report.Users)
+	}
+	return report
+}
+
+// precision cuenta qué parte de las k posiciones acertó una película oculta.
+// Sin posiciones no hay aciertos.
+func precision(results []Scored, hidden map[string]bool, k int) float64 {
+	if k <= 0 {
+		return 0
+	}
+	hits := 0
+	for _, result := range results {
+		if hidden[result.ImdbID] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(k)
+}
@@ -0,0 +1,85 @@
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Dos grupos de usuarios: unos ven todas las películas de acción y otros
+// todas las comedias.
+func evalFixture() ([]models.Interaction, []models.Movie, map[string][]models.Genre) {
+	action := models.Genre{GenreID: 1, GenreName: "Acción"}
+	comedy := models.Genre{GenreID: 2, GenreName: "Comedia"}
+
+	var catalogue []models.Movie
+	for i := range 6 {
+		catalogue = append(catalogue,
+			models.Movie{ImdbID: fmt.Sprintf("a%d", i), Genre: []models.Genre{action}},
+			models.Movie{ImdbID: fmt.Sprintf("c%d", i), Genre: []models.Genre{comedy}},
+		)
+	}
+
+	var interactions []models.Interaction
+	favourites := map[string][]models.Genre{}
+	for u := range 10 {
+		userID, prefix, genre := fmt.Sprintf("u%d", u), "a", action
+		if u%2 == 1 {
+			prefix, genre = "c", comedy
+		}
+		favourites[userID] = []models.Genre{genre}
+		for i := range 6 {
+			interactions = append(interactions, interaction(userID, fmt.Sprintf("%s%d", prefix, i), 1))
+		}
+		// Una que no le gustó del otro grupo
+		interactions = append(interactions, interaction(userID, fmt.Sprintf("x%d", u), -1))
+	}
+	return interactions, catalogue, favourites
+}
+
+func TestEvaluateIsSeedStable(t *testing.T) {
+	interactions, catalogue, favourites := evalFixture()
+	opts := EvalOptions{K: 3, Holdout: 0.3, MinInteractions: 5, Seed: 7}
+
+	first := Evaluate(interactions, catalogue, favourites, opts)
+	if first.Users != 10 || first.K != 3 {
+		t.Fatalf("reporte = %+v, se esperaban 10 usuarios y k = 3", first)
+	}
+	if first.Precision <= 0 || first.Precision > 1 {
+		t.Errorf("precisión = %v, fuera de (0, 1]", first.Precision)
+	}
+	for range 20 {
+		if again := Evaluate(interactions, catalogue, favourites, opts); again != first {
+			t.Fatalf("con la misma semilla: %+v y después %+v", first, again)
+		}
+	}
+}
+
+func TestEvaluateOptions(t *testing.T) {
+	interactions, catalogue, favourites := evalFixture()
+
+	tests := []struct {
+		name      string
+		opts      EvalOptions
+		wantUsers int
+	}{
+		// Cada usuario tiene seis interacciones positivas
+		{name: "pocas interacciones", opts: EvalOptions{K: 3, Holdout: 0.3, MinInteractions: 7, Seed: 1}, wantUsers: 0},
+		// Aunque se pida ocultar todo, queda una para entrenar
+		{name: "ocultar todo", opts: EvalOptions{K: 3, Holdout: 1, MinInteractions: 5, Seed: 1}, wantUsers: 10},
+		{name: "k cero", opts: EvalOptions{K: 0, Holdout: 0.3, MinInteractions: 5, Seed: 1}, wantUsers: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Evaluate(interactions, catalogue, favourites, tt.opts)
+			if report.Users != tt.wantUsers {
+				t.Errorf("usuarios evaluados = %d, se esperaban %d", report.Users, tt.wantUsers)
+			}
+			if math.IsNaN(report.Precision) || report.Precision < 0 || report.Precision > 1 {
+				t.Errorf("precisión = %v, fuera de [0, 1]", report.Precision)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package recommend calcula recomendaciones personales con filtrado
+// colaborativo ítem-ítem, mezclado con los géneros favoritos para los
+// usuarios con poca actividad.
+package recommend
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+const (
+	// Vecinos que se guardan por película
+	MaxNeighbours = 30
+	// Películas por usuario que entran al cálculo, las de más peso primero.
+	// Acota el costo cuadrático de los usuarios muy activos.
+	maxItemsPerUser = 500
+	// Reduce la similitud de pares con pocos usuarios en común:
+	// se multiplica por n / (n + shrinkage)
+	shrinkage = 3
+)
+
+// BuildNeighbours calcula las películas vecinas de cada película con la
+// similitud coseno entre los vectores de usuarios que las vieron.
+func BuildNeighbours(interactions []models.Interaction) map[string][]models.Neighbour {
+	byUser := map[string][]models.Interaction{}
+	for _, interaction := range interactions {
+		if interaction.Weight > 0 {
+			byUser[interaction.UserID] = append(byUser[interaction.UserID], interaction)
+		}
+	}
+
+	type pair struct{ a, b string }
+	type cooccurrence struct {
+		dot     float64
+		support int
+	}
+	norms := map[string]float64{}
+	pairs := map[pair]*cooccurrence{}
+
+	for _, items := range byUser {
+		if len(items) > maxItemsPerUser {
+			sort.Slice(items, func(i, j int) bool { return items[i].Weight > items[j].Weight })
+			items = items[:maxItemsPerUser]
+		}
+		for i, a := range items {
+			norms[a.ImdbID] += a.Weight * a.Weight
+			for _, b := range items[i+1:] {
+				key := pair{a.ImdbID, b.ImdbID}
+				if key.b < key.a {
+					key = pair{key.b, key.a}
+				}
+				co := pairs[key]
+				if co == nil {
+					co = &cooccurrence{}
+					pairs[key] = co
+				}
+				co.dot += a.Weight * b.Weight
+				co.support++
+			}
+		}
+	}
+
+	neighbours := map[string][]models.Neighbour{}
+	for key, co := range pairs {
+		score := co.dot / math.Sqrt(norms[key.a]*norms[key.b])
+		score *= float64(co.support) / float64(co.support+shrinkage)
+		score = math.Round(score*10000) / 10000
+		if score <= 0 {
+			continue
+		}
+		neighbours[key.a] = append(neighbours[key.a], models.Neighbour{ImdbID: key.b, Score: score})
+		neighbours[key.b] = append(neighbours[key.b], models.Neighbour{ImdbID: key.a, Score: score})
+	}
+
+	for imdbID, list := range neighbours {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Score != list[j].Score {
+				return list[i].Score > list[j].Score
+			}
+			return list[i].ImdbID < list[j].ImdbID
+		})
+		if len(list) > MaxNeighbours {
+			list = list[:MaxNeighbours]
+		}
+		neighbours[imdbID] = list
+	}
+	return neighbours
+}
@@ -0,0 +1,63 @@
+package recommend
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+func interaction(userID, imdbID string, weight float64) models.Interaction {
+	return models.Interaction{UserID: userID, ImdbID: imdbID, Weight: weight}
+}
+
+func TestBuildNeighbours(t *testing.T) {
+	var interactions []models.Interaction
+	// Tres usuarios ven A y B
+	for i := range 3 {
+		user := fmt.Sprintf("u%d", i)
+		interactions = append(interactions, interaction(user, "A", 1), interaction(user, "B", 1))
+	}
+	// B además la ven nueve usuarios solos: baja su coseno con A a 3/√48 ≈ 0,433
+	for i := range 9 {
+		interactions = append(interactions, interaction(fmt.Sprintf("b%d", i), "B", 1))
+	}
+	// Un solo usuario ve A y C: coseno 1/√4 = 0,5, mayor que el de B, pero
+	// con un único usuario en común
+	interactions = append(interactions, interaction("c", "A", 1), interaction("c", "C", 1))
+	// Las interacciones negativas no forman pares
+	interactions = append(interactions, interaction("d", "A", -1), interaction("d", "D", 1))
+
+	neighbours := BuildNeighbours(interactions)
+
+	// La reducción por pocos usuarios en común (n / (n+3)) invierte el orden:
+	// B = 0,433 × 3/6, C = 0,5 × 1/4
+	want := []models.Neighbour{{ImdbID: "B", Score: 0.2165}, {ImdbID: "C", Score: 0.125}}
+	if got := neighbours["A"]; !slices.Equal(got, want) {
+		t.Errorf("vecinas de A = %v, se esperaba %v", got, want)
+	}
+	if got := neighbours["C"]; !slices.Equal(got, []models.Neighbour{{ImdbID: "A", Score: 0.125}}) {
+		t.Errorf("vecinas de C = %v, la similitud debe ser simétrica", got)
+	}
+	if got, ok := neighbours["D"]; ok {
+		t.Errorf("D no comparte usuarios positivos con nadie y tiene vecinas %v", got)
+	}
+}
+
+func TestBuildNeighboursLimit(t *testing.T) {
+	var interactions []models.Interaction
+	for i := range MaxNeighbours + 10 {
+		user := fmt.Sprintf("u%d", i)
+		interactions = append(interactions, interaction(user, "A", 1), interaction(user, fmt.Sprintf("tt%03d", i), 1))
+	}
+
+	list := BuildNeighbours(interactions)["A"]
+	if len(list) != MaxNeighbours {
+		t.Fatalf("A tiene %d vecinas, se esperaban %d", len(list), MaxNeighbours)
+	}
+	// Con el mismo puntaje se ordenan por imdb_id
+	if list[0].ImdbID != "tt000" || list[len(list)-1].ImdbID != fmt.Sprintf("tt%03d", MaxNeighbours-1) {
+		t.Errorf("orden de empates: primera %s, última %s", list[0].ImdbID, list[len(list)-1].ImdbID)
+	}
+}
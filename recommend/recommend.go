@@ -0,0 +1,113 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Interacciones positivas a partir de las cuales el puntaje colaborativo pesa
+// por completo. Con menos se mezcla con los géneros favoritos en proporción.
+const coldStartInteractions = 5
+
+// Input son los datos para recomendar a un usuario.
+type Input struct {
+	// Interacciones del usuario; sus películas no se recomiendan
+	Interactions []models.Interaction
+	// Vecinas de las películas del usuario, por imdb_id
+	Neighbours map[string][]models.Neighbour
+	// Películas candidatas
+	Catalogue       []models.Movie
+	FavouriteGenres []models.Genre
+	Limit           int
+}
+
+// Scored es una película candidata con su puntaje final.
+type Scored struct {
+	ImdbID string
+	Score  float64
+	Source models.RecommendationSource
+}
+
+// Recommend ordena el catálogo para el usuario. El puntaje colaborativo suma
+// la similitud de cada candidata con las películas del usuario, pesada por
+// su interés, y se normaliza de 0 a 1. El de géneros es la parte de los
+// géneros de la película que están entre los favoritos. Con pocas
+// interacciones pesa más el de géneros.
+func Recommend(in Input) []Scored {
+	seen := map[string]bool{}
+	positives := 0
+	collaborative := map[string]float64{}
+	for _, interaction := range in.Interactions {
+		seen[interaction.ImdbID] = true
+		if interaction.Weight <= 0 {
+			continue
+		}
+		positives++
+		for _, neighbour := range in.Neighbours[interaction.ImdbID] {
+			collaborative[neighbour.ImdbID] += interaction.Weight * neighbour.Score
+		}
+	}
+
+	maxCollaborative := 0.0
+	for imdbID, score := range collaborative {
+		if !seen[imdbID] && score > maxCollaborative {
+			maxCollaborative = score
+		}
+	}
+
+	alpha := min(1, float64(positives)/coldStartInteractions)
+	favourites := map[int]bool{}
+	for _, genre := range in.FavouriteGenres {
+		favourites[genre.GenreID] = true
+	}
+
+	results := make([]Scored, 0, len(in.Catalogue))
+	for _, movie := range in.Catalogue {
+		if seen[movie.ImdbID] {
+			continue
+		}
+
+		cf := 0.0
+		if maxCollaborative > 0 {
+			cf = collaborative[movie.ImdbID] / maxCollaborative
+		}
+		genres := genreShare(movie.Genre, favourites)
+
+		cfPart, genrePart := alpha*cf, (1-alpha)*genres
+		if cfPart+genrePart == 0 {
+			continue
+		}
+		source := models.SourceCollaborative
+		if genrePart > cfPart {
+			source = models.SourceGenres
+		}
+		results = append(results, Scored{ImdbID: movie.ImdbID, Score: math.Round((cfPart+genrePart)*10000) / 10000, Source: source})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ImdbID < results[j].ImdbID
+	})
+	if in.Limit > 0 && len(results) > in.Limit {
+		results = results[:in.Limit]
+	}
+	return results
+}
+
+// genreShare devuelve qué parte de los géneros de la película son favoritos.
+func genreShare(genres []models.Genre, favourites map[int]bool) float64 {
+	if len(genres) == 0 || len(favourites) == 0 {
+		return 0
+	}
+	matches := 0
+	for _, genre := range genres {
+		if favourites[genre.GenreID] {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(genres))
+}
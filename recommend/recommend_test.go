@@ -0,0 +1,98 @@
+package recommend
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Con n interacciones positivas el colaborativo pesa n/5 y los géneros el resto.
+func TestRecommendBlending(t *testing.T) {
+	drama := models.Genre{GenreID: 1, GenreName: "Drama"}
+	comedy := models.Genre{GenreID: 2, GenreName: "Comedia"}
+	catalogue := []models.Movie{
+		// Vecina de todo lo que vio el usuario, de un género que no le gusta
+		{ImdbID: "cf", Genre: []models.Genre{drama}},
+		// Sin vecinas, del género favorito
+		{ImdbID: "genre", Genre: []models.Genre{comedy}},
+		// Nada en común
+		{ImdbID: "other", Genre: []models.Genre{drama}},
+	}
+	neighbours := map[string][]models.Neighbour{}
+	for i := range 10 {
+		seen := fmt.Sprintf("seen%d", i)
+		catalogue = append(catalogue, models.Movie{ImdbID: seen, Genre: []models.Genre{drama}})
+		neighbours[seen] = []models.Neighbour{{ImdbID: "cf", Score: 0.5}}
+	}
+
+	tests := []struct {
+		name      string
+		positives int
+		want      []Scored
+	}{
+		{
+			name: "sin actividad: solo géneros",
+			want: []Scored{{ImdbID: "genre", Score: 1, Source: models.SourceGenres}},
+		},
+		{
+			name:      "una interacción",
+			positives: 1,
+			want: []Scored{
+				{ImdbID: "genre", Score: 0.8, Source: models.SourceGenres},
+				{ImdbID: "cf", Score: 0.2, Source: models.SourceCollaborative},
+			},
+		},
+		{
+			name:      "tres interacciones",
+			positives: 3,
+			want: []Scored{
+				{ImdbID: "cf", Score: 0.6, Source: models.SourceCollaborative},
+				{ImdbID: "genre", Score: 0.4, Source: models.SourceGenres},
+			},
+		},
+		{
+			name:      "suficiente actividad: solo colaborativo",
+			positives: 5,
+			want:      []Scored{{ImdbID: "cf", Score: 1, Source: models.SourceCollaborative}},
+		},
+		{
+			name:      "más actividad no pasa de 1",
+			positives: 10,
+			want:      []Scored{{ImdbID: "cf", Score: 1, Source: models.SourceCollaborative}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var interactions []models.Interaction
+			for i := range tt.positives {
+				interactions = append(interactions, interaction("u", fmt.Sprintf("seen%d", i), 1))
+			}
+			// Lo que no le gustó no se recomienda ni cuenta como actividad
+			interactions = append(interactions, interaction("u", "other", -1))
+
+			got := Recommend(Input{
+				Interactions:    interactions,
+				Neighbours:      neighbours,
+				Catalogue:       catalogue,
+				FavouriteGenres: []models.Genre{comedy},
+			})
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Recommend = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendLimit(t *testing.T) {
+	comedy := models.Genre{GenreID: 2, GenreName: "Comedia"}
+	catalogue := make([]models.Movie, 10)
+	for i := range catalogue {
+		catalogue[i] = models.Movie{ImdbID: fmt.Sprintf("tt%d", i), Genre: []models.Genre{comedy}}
+	}
+
+	got := Recommend(Input{Catalogue: catalogue, FavouriteGenres: []models.Genre{comedy}, Limit: 3})
+	if len(got) != 3 || got[0].ImdbID != "tt0" || got[2].ImdbID != "tt2" {
+		t.Errorf("Recommend con límite 3 = %v, se esperaban tt0, tt1 y tt2", got)
+	}
+}
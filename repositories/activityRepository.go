@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ActivityRepository guarda los eventos de actividad usados para la popularidad.
+type ActivityRepository struct {
+	collection *mongo.Collection
+}
+
+func NewActivityRepository(db *mongo.Database) *ActivityRepository {
+	return &ActivityRepository{collection: db.Collection(database.ActivityCollection)}
+}
+
+// Record guarda un evento. Si no trae fecha se usa la actual.
+func (r *ActivityRepository) Record(ctx context.Context, event models.ActivityEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+// ActivityBuckets agrupa los eventos desde since por película, tipo e
+// intervalos de duración size.
+func (r *ActivityRepository) ActivityBuckets(ctx context.Context, since time.Time, size time.Duration) ([]models.ActivityBucket, error) {
+	millis := bson.M{"$toLong": "$created_at"}
+	start := bson.M{"$toDate": bson.M{"$subtract": bson.A{millis, bson.M{"$mod": bson.A{millis, size.Milliseconds()}}}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"imdb_id": "$imdb_id", "kind": "$kind", "start": start},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":     0,
+			"imdb_id": "$_id.imdb_id",
+			"kind":    "$_id.kind",
+			"start":   "$_id.start",
+			"count":   1,
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var buckets []models.ActivityBucket
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
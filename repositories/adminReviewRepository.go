@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/textdiff"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Intentos de numerar una versión cuando otro administrador guarda a la vez
+const maxVersionAttempts = 5
+
+// AdminReviewRepository cambia la reseña del administrador de las películas
+// guardando cada texto como una versión.
+type AdminReviewRepository struct {
+	history *mongo.Collection
+	movies  *MovieRepository
+}
+
+func NewAdminReviewRepository(db *mongo.Database, movies *MovieRepository) *AdminReviewRepository {
+	return &AdminReviewRepository{history: db.Collection(database.AdminReviewHistoryCollection), movies: movies}
+}
+
+// AdminReviewAuthor identifica al administrador que cambia una reseña.
+type AdminReviewAuthor struct {
+	ID   string
+	Name string
+}
+
+// Update cambia la reseña de la película y registra la versión nueva.
+// revertedFrom es la versión restaurada, o 0 si es un cambio normal.
+// Si el texto anterior no estaba en el historial (reseñas cargadas por seed o
+// importación) se guarda primero como versión sin autor.
+//
+// La versión se guarda antes de cambiar la película, y la película solo
+// cambia si nadie la modificó mientras tanto; si otro cambio se adelantó se
+// descarta la versión y se vuelve a intentar. Así el texto publicado siempre
+// tiene su versión en el historial.
+func (r *AdminReviewRepository) Update(ctx context.Context, imdbID, text string, author AdminReviewAuthor, revertedFrom int) (*models.AdminReviewVersion, error) {
+	for attempt := 0; ; attempt++ {
+		previous, err := r.movies.AdminReview(ctx, imdbID)
+		if err != nil {
+			return nil, err
+		}
+
+		latest, err := r.latest(ctx, imdbID)
+		if err != nil {
+			return nil, err
+		}
+		if latestText(latest) != previous {
+			latest, err = r.insert(ctx, models.AdminReviewVersion{
+				ImdbID: imdbID,
+				Text:   previous,
+				Diff:   textdiff.Words(latestText(latest), previous),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if latest != nil && previous == text {
+			return latest, nil
+		}
+
+		version, err := r.insert(ctx, models.AdminReviewVersion{
+			ImdbID:       imdbID,
+			Text:         text,
+			AuthorID:     author.ID,
+			AuthorName:   author.Name,
+			Diff:         textdiff.Words(previous, text),
+			RevertedFrom: revertedFrom,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		replaced, err := r.movies.ReplaceAdminReview(ctx, imdbID, previous, text)
+		if err == nil && replaced {
+			return version, nil
+		}
+		// La versión no llegó a publicarse
+		if _, deleteErr := r.history.DeleteOne(ctx, bson.D{{Key: "_id", Value: version.ID}}); deleteErr != nil {
+			return nil, errors.Join(err, deleteErr)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if attempt >= maxVersionAttempts {
+			return nil, errors.New("la reseña cambió mientras se guardaba; se agotaron los reintentos")
+		}
+	}
+}
+
+// AdminReviewHistory es una página del historial, de la versión más nueva a la más vieja.
+type AdminReviewHistory struct {
+	Versions []models.AdminReviewVersion `json:"versions"`
+	Total    int64                       `json:"total"`
+	Page     int                         `json:"page"`
+	PageSize int                         `json:"page_size"`
+}
+
+// History devuelve una página del historial de la reseña de una película.
+func (r *AdminReviewRepository) History(ctx context.Context, imdbID string, page, pageSize int) (*AdminReviewHistory, error) {
+	filter := bson.D{{Key: "imdb_id", Value: imdbID}}
+	total, err := r.history.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := r.history.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	versions := []models.AdminReviewVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	return &AdminReviewHistory{Versions: versions, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+// FindVersion devuelve una versión de la reseña o ErrNotFound.
+func (r *AdminReviewRepository) FindVersion(ctx context.Context, imdbID string, version int) (*models.AdminReviewVersion, error) {
+	var found models.AdminReviewVersion
+	filter := bson.D{{Key: "imdb_id", Value: imdbID}, {Key: "version", Value: version}}
+	err := r.history.FindOne(ctx, filter).Decode(&found)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// latest devuelve la última versión, o nil si la película no tiene historial.
+func (r *AdminReviewRepository) latest(ctx context.Context, imdbID string) (*models.AdminReviewVersion, error) {
+	var found models.AdminReviewVersion
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	err := r.history.FindOne(ctx, bson.D{{Key: "imdb_id", Value: imdbID}}, opts).Decode(&found)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// insert guarda la versión con el número siguiente al último. El índice único
+// (imdb_id, version) evita que dos cambios simultáneos usen el mismo número.
+func (r *AdminReviewRepository) insert(ctx context.Context, version models.AdminReviewVersion) (*models.AdminReviewVersion, error) {
+	for attempt := 0; ; attempt++ {
+		latest, err := r.latest(ctx, version.ImdbID)
+		if err != nil {
+			return nil, err
+		}
+		version.ID = bson.NewObjectID()
+		version.Version = 1
+		if latest != nil {
+			version.Version = latest.Version + 1
+		}
+		version.CreatedAt = time.Now()
+
+		_, err = r.history.InsertOne(ctx, version)
+		if mongo.IsDuplicateKeyError(err) && attempt < maxVersionAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &version, nil
+	}
+}
+
+func latestText(version *models.AdminReviewVersion) string {
+	if version == nil {
+		return ""
+	}
+	return version.Text
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Intentos de asignar un genre_id cuando otro administrador crea un género a la vez
+const maxGenreIDAttempts = 5
+
+// GenreRepository maneja los géneros y las copias {genre_id, genre_name}
+// que películas y usuarios guardan de ellos.
+type GenreRepository struct {
+	collection *mongo.Collection
+	movies     *mongo.Collection
+	users      *mongo.Collection
+	listeners  []func()
+}
+
+func NewGenreRepository(db *mongo.Database) *GenreRepository {
+	return &GenreRepository{
+		collection: db.Collection(database.GenresCollection),
+		movies:     db.Collection(database.MoviesCollection),
+		users:      db.Collection(database.UsersCollection),
+	}
+}
+
+// OnChange registra una función que se llama cuando cambia algún género.
+func (r *GenreRepository) OnChange(fn func()) {
+	r.listeners = append(r.listeners, fn)
+}
+
+func (r *GenreRepository) changed() {
+	for _, fn := range r.listeners {
+		fn()
+	}
+}
+
+// GenreUsage cuenta cuántos documentos usan un género.
+type GenreUsage struct {
+	Movies int64 `json:"movies"`
+	Users  int64 `json:"users"`
+}
+
+// InUse indica si algún documento usa el género.
+func (u GenreUsage) InUse() bool {
+	return u.Movies > 0 || u.Users > 0
+}
+
+// Obtener géneros
+func (r *GenreRepository) FindAll(ctx context.Context) ([]models.Genre, error) {
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{Key: "genre_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var genres []models.Genre
+	if err := cursor.All(ctx, &genres); err != nil {
+		return nil, err
+	}
+	return genres, nil
+}
+
+func (r *GenreRepository) FindByID(ctx context.Context, genreID int) (*models.Genre, error) {
+	var genre models.Genre
+	err := r.collection.FindOne(ctx, bson.D{{Key: "genre_id", Value: genreID}}).Decode(&genre)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+// Catalog devuelve todos los géneros indexados por id, para validar referencias.
+func (r *GenreRepository) Catalog(ctx context.Context) (models.GenreCatalog, error) {
+	genres, err := r.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(models.GenreCatalog, len(genres))
+	for _, genre := range genres {
+		catalog[genre.GenreID] = genre
+	}
+	return catalog, nil
+}
+
+// Inserta un género. Si no trae genre_id se le asigna el siguiente libre;
+// si otro género toma ese id al mismo tiempo se prueba con el siguiente.
+// Devuelve ErrDuplicate si el id pedido o el nombre ya existen.
+func (r *GenreRepository) Insert(ctx context.Context, genre models.Genre) (*models.Genre, error) {
+	assigned := genre.GenreID == 0
+	for attempt := 0; ; attempt++ {
+		if assigned {
+			var last models.Genre
+			err := r.collection.FindOne(ctx, bson.D{}, options.FindOne().SetSort(bson.D{{Key: "genre_id", Value: -1}})).Decode(&last)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+			genre.GenreID = last.GenreID + 1
+		}
+
+		_, err := r.collection.InsertOne(ctx, genre)
+		if mongo.IsDuplicateKeyError(err) {
+			if assigned && !duplicateName(err) && attempt < maxGenreIDAttempts {
+				continue
+			}
+			return nil, ErrDuplicate
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.changed()
+		return &genre, nil
+	}
+}
+
+// duplicateName indica que la clave duplicada es el nombre y no el id.
+func duplicateName(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCodeWithMessage(11000, "genre_name")
+}
+
+// Update cambia el nombre del género (y sus nombres por idioma si se
+// indican) y propaga genre_name a las copias guardadas en películas y
+// usuarios. Las escrituras no son atómicas entre colecciones, pero repetir la
+// actualización deja todo consistente.
+func (r *GenreRepository) Update(ctx context.Context, genre models.Genre) (*models.Genre, error) {
+	genreID, name := genre.GenreID, genre.GenreName
+	set := bson.M{"genre_name": name}
+	if genre.Names != nil {
+		set["names"] = genre.Names
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.D{{Key: "genre_id", Value: genreID}},
+		bson.M{"$set": set},
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicate
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, ErrNotFound
+	}
+
+	if err := r.renameCopies(ctx, r.movies, "genre", genreID, name); err != nil {
+		return nil, err
+	}
+	if err := r.renameCopies(ctx, r.users, "favourite_genres", genreID, name); err != nil {
+		return nil, err
+	}
+
+	r.changed()
+	return r.FindByID(ctx, genreID)
+}
+
+func (r *GenreRepository) renameCopies(ctx context.Context, collection *mongo.Collection, field string, genreID int, name string) error {
+	_, err := collection.UpdateMany(ctx,
+		bson.D{{Key: field + ".genre_id", Value: genreID}},
+		bson.M{"$set": bson.M{field + ".$[g].genre_name": name}},
+		options.UpdateMany().SetArrayFilters([]any{bson.M{"g.genre_id": genreID}}),
+	)
+	return err
+}
+
+// Usage cuenta las películas y usuarios que usan el género.
+func (r *GenreRepository) Usage(ctx context.Context, genreID int) (GenreUsage, error) {
+	var usage GenreUsage
+	var err error
+
+	usage.Movies, err = r.movies.CountDocuments(ctx, bson.D{{Key: "genre.genre_id", Value: genreID}})
+	if err != nil {
+		return usage, err
+	}
+	usage.Users, err = r.users.CountDocuments(ctx, bson.D{{Key: "favourite_genres.genre_id", Value: genreID}})
+	return usage, err
+}
+
+// Delete borra un género que nadie usa. Si está en uso devuelve *GenreInUseError.
+func (r *GenreRepository) Delete(ctx context.Context, genreID int) error {
+	usage, err := r.Usage(ctx, genreID)
+	if err != nil {
+		return err
+	}
+	if usage.InUse() {
+		return &GenreInUseError{Usage: usage}
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "genre_id", Value: genreID}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	r.changed()
+	return nil
+}
+
+// GenreInUseError indica que el género no se puede borrar porque está en uso.
+type GenreInUseError struct {
+	Usage GenreUsage
+}
+
+func (e *GenreInUseError) Error() string {
+	return "el género está en uso"
+}
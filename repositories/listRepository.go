@@ -0,0 +1,300 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Límites de las listas de películas
+const (
+	MaxListItems = 1000
+	// Largo del slug sin el sufijo aleatorio
+	maxSlugLength = 40
+	// Largo del sufijo aleatorio que hace único al slug
+	slugSuffixLength = 10
+	// Intentos de generar un slug libre
+	maxSlugAttempts = 5
+)
+
+// Errores de las listas de películas
+var (
+	ErrListFull     = errors.New("la lista llegó al máximo de películas")
+	ErrInvalidOrder = errors.New("el nuevo orden debe tener exactamente las películas de la lista")
+)
+
+// ListRepository guarda la lista de pendientes y las listas de cada usuario.
+type ListRepository struct {
+	collection *mongo.Collection
+}
+
+func NewListRepository(db *mongo.Database) *ListRepository {
+	return &ListRepository{collection: db.Collection(database.ListsCollection)}
+}
+
+// Watchlist devuelve la lista de pendientes del usuario y la crea si no existe.
+func (r *ListRepository) Watchlist(ctx context.Context, userID string) (*models.MovieList, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "kind", Value: models.ListWatchlist}}
+
+	for attempt := 0; ; attempt++ {
+		now := time.Now()
+		update := bson.M{"$setOnInsert": bson.M{
+			"name":       "Watchlist",
+			"slug":       newSlug("watchlist"),
+			"visibility": models.ListPrivate,
+			"items":      bson.A{},
+			"created_at": now,
+			"updated_at": now,
+		}}
+		opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+		var list models.MovieList
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&list)
+		if mongo.IsDuplicateKeyError(err) && attempt < maxSlugAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &list, nil
+	}
+}
+
+// FindByUser devuelve las listas del usuario, la de pendientes primero.
+func (r *ListRepository) FindByUser(ctx context.Context, userID string) ([]models.MovieList, error) {
+	watchlist, err := r.Watchlist(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "kind", Value: models.ListCustom}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	lists := []models.MovieList{*watchlist}
+	for cursor.Next(ctx) {
+		var list models.MovieList
+		if err := cursor.Decode(&list); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, cursor.Err()
+}
+
+// FindByID devuelve una lista del usuario o ErrNotFound.
+func (r *ListRepository) FindByID(ctx context.Context, userID string, id bson.ObjectID) (*models.MovieList, error) {
+	return r.findOne(ctx, bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}})
+}
+
+// FindPublic devuelve la lista pública con el slug indicado o ErrNotFound.
+func (r *ListRepository) FindPublic(ctx context.Context, slug string) (*models.MovieList, error) {
+	return r.findOne(ctx, bson.D{{Key: "slug", Value: slug}, {Key: "visibility", Value: models.ListPublic}})
+}
+
+// Create guarda una lista nueva del usuario con un slug único.
+func (r *ListRepository) Create(ctx context.Context, userID, name string, visibility models.ListVisibility) (*models.MovieList, error) {
+	now := time.Now()
+	list := models.MovieList{
+		UserID:     userID,
+		Name:       name,
+		Kind:       models.ListCustom,
+		Visibility: visibility,
+		Items:      []models.ListItem{},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	for attempt := 0; ; attempt++ {
+		list.ID = bson.NewObjectID()
+		list.Slug = newSlug(name)
+		_, err := r.collection.InsertOne(ctx, list)
+		if mongo.IsDuplicateKeyError(err) && attempt < maxSlugAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &list, nil
+	}
+}
+
+// Update cambia el nombre y la visibilidad de una lista. Los valores vacíos
+// no se cambian. El slug se mantiene para no romper los enlaces compartidos.
+func (r *ListRepository) Update(ctx context.Context, userID string, id bson.ObjectID, name string, visibility models.ListVisibility) (*models.MovieList, error) {
+	set := bson.M{"updated_at": time.Now()}
+	if name != "" {
+		set["name"] = name
+	}
+	if visibility != "" {
+		set["visibility"] = visibility
+	}
+	return r.update(ctx, bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}}, bson.M{"$set": set})
+}
+
+// Delete borra una lista del usuario. La lista de pendientes no se borra.
+func (r *ListRepository) Delete(ctx context.Context, userID string, id bson.ObjectID) error {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}, {Key: "kind", Value: models.ListCustom}}
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddMovie agrega la película al final de la lista. Si ya estaba no cambia nada.
+func (r *ListRepository) AddMovie(ctx context.Context, userID string, id bson.ObjectID, imdbID string) (*models.MovieList, error) {
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "user_id", Value: userID},
+		{Key: "items.imdb_id", Value: bson.M{"$ne": imdbID}},
+		// La lista todavía no tiene MaxListItems películas
+		{Key: "items." + strconv.Itoa(MaxListItems-1), Value: bson.M{"$exists": false}},
+	}
+	update := bson.M{
+		"$push": bson.M{"items": models.ListItem{ImdbID: imdbID, AddedAt: time.Now()}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	list, err := r.update(ctx, filter, update)
+	if !errors.Is(err, ErrNotFound) {
+		return list, err
+	}
+
+	// No coincidió: la lista no existe, la película ya estaba o está llena.
+	list, err = r.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list.Items {
+		if item.ImdbID == imdbID {
+			return list, nil
+		}
+	}
+	return nil, ErrListFull
+}
+
+// RemoveMovie quita la película de la lista.
+func (r *ListRepository) RemoveMovie(ctx context.Context, userID string, id bson.ObjectID, imdbID string) (*models.MovieList, error) {
+	update := bson.M{
+		"$pull": bson.M{"items": bson.M{"imdb_id": imdbID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	return r.update(ctx, bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}}, update)
+}
+
+// Reorder ordena la lista según imdbIDs, que debe tener exactamente las
+// mismas películas. Devuelve ErrInvalidOrder si no coinciden.
+func (r *ListRepository) Reorder(ctx context.Context, userID string, id bson.ObjectID, imdbIDs []string) (*models.MovieList, error) {
+	list, err := r.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[string]models.ListItem, len(list.Items))
+	for _, item := range list.Items {
+		items[item.ImdbID] = item
+	}
+	if len(imdbIDs) != len(items) {
+		return nil, ErrInvalidOrder
+	}
+	ordered := make([]models.ListItem, 0, len(imdbIDs))
+	for _, imdbID := range imdbIDs {
+		item, ok := items[imdbID]
+		if !ok {
+			return nil, ErrInvalidOrder
+		}
+		delete(items, imdbID)
+		ordered = append(ordered, item)
+	}
+
+	// Solo se aplica si la lista no cambió desde que se leyó.
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "user_id", Value: userID}, {Key: "updated_at", Value: list.UpdatedAt}}
+	update := bson.M{"$set": bson.M{"items": ordered, "updated_at": time.Now()}}
+	list, err = r.update(ctx, filter, update)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrInvalidOrder
+	}
+	return list, err
+}
+
+// Memberships devuelve, por imdb_id, en qué listas del usuario está cada película.
+func (r *ListRepository) Memberships(ctx context.Context, userID string) (map[string]*models.ListMembership, error) {
+	opts := options.Find().SetProjection(bson.M{"kind": 1, "items.imdb_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: "user_id", Value: userID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	memberships := map[string]*models.ListMembership{}
+	for cursor.Next(ctx) {
+		var list models.MovieList
+		if err := cursor.Decode(&list); err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			membership, ok := memberships[item.ImdbID]
+			if !ok {
+				membership = &models.ListMembership{ListIDs: []string{}}
+				memberships[item.ImdbID] = membership
+			}
+			if list.Kind == models.ListWatchlist {
+				membership.InWatchlist = true
+			}
+			membership.ListIDs = append(membership.ListIDs, list.ID.Hex())
+		}
+	}
+	return memberships, cursor.Err()
+}
+
+func (r *ListRepository) findOne(ctx context.Context, filter bson.D) (*models.MovieList, error) {
+	var list models.MovieList
+	err := r.collection.FindOne(ctx, filter).Decode(&list)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *ListRepository) update(ctx context.Context, filter bson.D, update bson.M) (*models.MovieList, error) {
+	var list models.MovieList
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&list)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// newSlug arma el slug de una lista: el nombre normalizado y un sufijo
+// aleatorio, para que el enlace no se pueda adivinar.
+func newSlug(name string) string {
+	slug := utils.Slugify(name, maxSlugLength)
+	suffix := strings.ToLower(rand.Text()[:slugSuffixLength])
+	if slug == "" {
+		return suffix
+	}
+	return slug + "-" + suffix
+}
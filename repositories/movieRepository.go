@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type MovieRepository struct {
+	collection *mongo.Collection
+	listeners  []func()
+}
+
+func NewMovieRepository(db *mongo.Database) *MovieRepository {
+	return &MovieRepository{collection: db.Collection(database.MoviesCollection)}
+}
+
+// OnChange registra una función que se llama después de cada escritura en
+// películas, para que los índices en memoria se actualicen. Debe registrarse
+// antes de empezar a atender pedidos.
+func (r *MovieRepository) OnChange(fn func()) {
+	r.listeners = append(r.listeners, fn)
+}
+
+// changed avisa a los listeners. Dentro de WithTransaction el aviso se
+// guarda hasta que la transacción confirma: antes los índices podrían
+// reconstruirse con cambios que después se descartan.
+func (r *MovieRepository) changed(ctx context.Context) {
+	if pending, ok := ctx.Value(pendingChangeKey{}).(*bool); ok {
+		*pending = true
+		return
+	}
+	r.notify()
+}
+
+func (r *MovieRepository) notify() {
+	for _, fn := range r.listeners {
+		fn()
+	}
+}
+
+// Clave del contexto con el aviso pendiente de la transacción en curso
+type pendingChangeKey struct{}
+
+// MovieSort es el orden de un listado de películas.
+type MovieSort string
+
+const (
+	// Orden de inserción
+	SortDefault MovieSort = ""
+	// Mejor promedio de los usuarios primero; a igual promedio, más votos
+	SortUserScore MovieSort = "user_score"
+	// Más popular en el último día primero
+	SortPopularity MovieSort = "popularity"
+)
+
+// Obtener todas las películas
+func (r *MovieRepository) FindAll(ctx context.Context) ([]models.Movie, error) {
+	return r.FindAllSorted(ctx, SortDefault)
+}
+
+// Obtener todas las películas en el orden indicado
+func (r *MovieRepository) FindAllSorted(ctx context.Context, sort MovieSort) ([]models.Movie, error) {
+	opts := options.Find()
+	if sort == SortUserScore {
+		opts.SetSort(bson.D{{Key: "user_score.average", Value: -1}, {Key: "user_score.count", Value: -1}})
+	}
+	if sort == SortPopularity {
+		opts.SetSort(bson.D{{Key: "popularity.daily", Value: -1}})
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []models.Movie
+	if err := cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+// FindCandidates devuelve las películas indicadas y las que tienen alguno de
+// los géneros, sin recorrer el resto del catálogo.
+func (r *MovieRepository) FindCandidates(ctx context.Context, imdbIDs []string, genreIDs []int) ([]models.Movie, error) {
+	if len(imdbIDs) == 0 && len(genreIDs) == 0 {
+		return []models.Movie{}, nil
+	}
+	filter := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "imdb_id", Value: bson.D{{Key: "$in", Value: imdbIDs}}}},
+		bson.D{{Key: "genre.genre_id", Value: bson.D{{Key: "$in", Value: genreIDs}}}},
+	}}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	movies := []models.Movie{}
+	if err := cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+// FindTrending devuelve hasta limit películas con actividad en la ventana
+// indicada (hourly, daily o weekly), la más popular primero.
+func (r *MovieRepository) FindTrending(ctx context.Context, window string, limit int) ([]models.Movie, error) {
+	field := "popularity." + window
+	opts := options.Find().SetSort(bson.D{{Key: field, Value: -1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.D{{Key: field, Value: bson.M{"$gt": 0}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	movies := []models.Movie{}
+	if err := cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
+// SetPopularity guarda los puntajes calculados en updatedAt y borra la
+// popularidad de las películas que ya no tienen actividad reciente.
+func (r *MovieRepository) SetPopularity(ctx context.Context, scores map[string]models.Popularity, updatedAt time.Time) error {
+	if len(scores) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(scores))
+		for imdbID, popularity := range scores {
+			writes = append(writes, mongo.NewUpdateOneModel().
+				SetFilter(bson.D{{Key: "imdb_id", Value: imdbID}}).
+				SetUpdate(bson.M{"$set": bson.M{"popularity": popularity}}))
+		}
+		if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"popularity.updated_at": bson.M{"$lt": updatedAt}},
+		bson.M{"$unset": bson.M{"popularity": ""}},
+	)
+	return err
+}
+
+// Obtener id, título y póster de todas las películas
+func (r *MovieRepository) FindSummaries(ctx context.Context) ([]models.MovieSummary, error) {
+	projection := bson.M{"imdb_id": 1, "title": 1, "poster_path": 1}
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var summaries []models.MovieSummary
+	if err := cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// Obtener una película por IMDB ID
+func (r *MovieRepository) FindByImdbID(ctx context.Context, imdbID string) (*models.Movie, error) {
+	var movie models.Movie
+	err := r.collection.FindOne(ctx, bson.D{{Key: "imdb_id", Value: imdbID}}).Decode(&movie)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &movie, nil
+}
+
+// Inserta una película. El índice único de imdb_id devuelve ErrDuplicate si ya existe.
+func (r *MovieRepository) Insert(ctx context.Context, movie models.Movie) (*mongo.InsertOneResult, error) {
+	movie.UserScore = nil
+	movie.Popularity = nil
+	result, err := r.collection.InsertOne(ctx, movie)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicate
+	}
+	if err == nil {
+		r.changed(ctx)
+	}
+	return result, err
+}
+
+// AdminReview devuelve la reseña del administrador de la película, o
+// ErrNotFound si la película no existe.
+func (r *MovieRepository) AdminReview(ctx context.Context, imdbID string) (string, error) {
+	var movie models.Movie
+	opts := options.FindOne().SetProjection(bson.M{"admin_review": 1})
+	err := r.collection.FindOne(ctx, bson.D{{Key: "imdb_id", Value: imdbID}}, opts).Decode(&movie)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return movie.AdminReview, nil
+}
+
+// ReplaceAdminReview cambia la reseña del administrador solo si todavía es
+// previous. Devuelve false si otro cambio se adelantó o la película no existe.
+func (r *MovieRepository) ReplaceAdminReview(ctx context.Context, imdbID, previous, review string) (bool, error) {
+	current := any(previous)
+	if previous == "" {
+		// Las películas cargadas sin reseña pueden no tener el campo
+		current = bson.D{{Key: "$in", Value: bson.A{"", nil}}}
+	}
+	filter := bson.D{{Key: "imdb_id", Value: imdbID}, {Key: "admin_review", Value: current}}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"admin_review": review}})
+	if err != nil {
+		return false, err
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+	r.changed(ctx)
+	return true, nil
+}
+
+// UpsertResult resume una escritura masiva de películas.
+type UpsertResult struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+	// Errores de escritura por índice dentro del lote
+	Errors map[int]error
+}
+
+// UpsertMany inserta o actualiza las películas por imdb_id en una sola escritura
+// masiva. Los errores de cada documento se devuelven en UpsertResult.Errors.
+// En las películas existentes solo se cambian los campos que vienen con valor
+// y nunca la reseña del administrador, que tiene su propio historial.
+func (r *MovieRepository) UpsertMany(ctx context.Context, movies []models.Movie) (*UpsertResult, error) {
+	result := &UpsertResult{Errors: map[int]error{}}
+	if len(movies) == 0 {
+		return result, nil
+	}
+
+	writes := make([]mongo.WriteModel, len(movies))
+	for i, movie := range movies {
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "imdb_id", Value: movie.ImdbID}}).
+			SetUpdate(MovieUpsert(movie)).
+			SetUpsert(true)
+	}
+
+	bulkResult, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			result.Errors[writeErr.Index] = writeErr
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if bulkResult != nil {
+		result.Inserted = int(bulkResult.UpsertedCount)
+		result.Updated = int(bulkResult.ModifiedCount)
+		result.Unchanged = int(bulkResult.MatchedCount - bulkResult.ModifiedCount)
+	}
+	if result.Inserted > 0 || result.Updated > 0 {
+		r.changed(ctx)
+	}
+	return result, nil
+}
+
+// MovieUpsert arma la actualización de una película importada o cargada por
+// seed. Los campos obligatorios siempre se escriben; los opcionales vacíos
+// (una columna que falta en el CSV, una clave que falta en el JSON) solo se
+// completan al insertar, igual que admin_review, que después se edita con su
+// historial de versiones.
+func MovieUpsert(movie models.Movie) bson.M {
+	set := bson.M{
+		"imdb_id":     movie.ImdbID,
+		"title":       movie.Title,
+		"poster_path": movie.PosterPath,
+		"youtube_id":  movie.YouTubeID,
+		"genre":       movie.Genre,
+	}
+	onInsert := bson.M{"admin_review": movie.AdminReview}
+
+	optional := func(field string, value any, present bool) {
+		if present {
+			set[field] = value
+		} else {
+			onInsert[field] = value
+		}
+	}
+	optional("description", movie.Description, movie.Description != "")
+	optional("watch_url", movie.WatchURL, movie.WatchURL != "")
+	optional("ranking", movie.Ranking, movie.Ranking != models.Ranking{})
+	if movie.ReleaseYear != 0 {
+		set["release_year"] = movie.ReleaseYear
+	}
+	// Por idioma, para no borrar las traducciones que no vienen en la fila
+	for lang, translation := range movie.Translations {
+		set["translations."+lang] = translation
+	}
+
+	return bson.M{"$set": set, "$setOnInsert": onInsert}
+}
+
+// WithTransaction ejecuta fn dentro de una transacción y avisa los cambios
+// recién cuando confirma. Requiere que MongoDB corra como replica set.
+func (r *MovieRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	pending := false
+	ctx = context.WithValue(ctx, pendingChangeKey{}, &pending)
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		// Un reintento empieza de cero: los cambios del intento abortado no cuentan
+		pending = false
+		return nil, fn(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	if pending {
+		r.notify()
+	}
+	return nil
+}
@@ -0,0 +1,350 @@
+package repositories
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// GenreMatch indica si una película debe tener alguno o todos los géneros pedidos.
+type GenreMatch string
+
+const (
+	MatchAny GenreMatch = "any"
+	MatchAll GenreMatch = "all"
+)
+
+// MovieQuery describe los filtros de búsqueda del catálogo. Los valores cero
+// (o nil) no filtran.
+type MovieQuery struct {
+	// Texto libre buscado en título, descripción y reseña
+	Text string
+	// Géneros por id ("2") o por nombre ("Drama")
+	Genres     []string
+	GenreMatch GenreMatch
+	RankingMin int
+	RankingMax int
+	YearFrom   int
+	YearTo     int
+	// Filtros por presencia de reseña, youtube_id y watch_url
+	HasReview   *bool
+	HasTrailer  *bool
+	HasWatchURL *bool
+	// Página (desde 1) y tamaño de página. Cero devuelve todos los resultados.
+	Page     int
+	PageSize int
+}
+
+// SearchMode indica cómo se resolvió la búsqueda de texto.
+type SearchMode string
+
+const (
+	// SearchAll no tiene texto: se listan las películas por título.
+	SearchAll SearchMode = "all"
+	// SearchText usa el índice de texto y ordena por relevancia.
+	SearchText SearchMode = "text"
+	// SearchPartial busca el texto dentro del título cuando la búsqueda por
+	// palabras completas no encontró nada (por ejemplo "pilo" → "piloto").
+	SearchPartial SearchMode = "partial"
+)
+
+// GenreFacet es la cantidad de resultados de un género.
+type GenreFacet struct {
+	GenreID   int    `bson:"_id" json:"genre_id"`
+	GenreName string `bson:"genre_name" json:"genre_name"`
+	Count     int    `bson:"count" json:"count"`
+}
+
+// RankingFacet es la cantidad de resultados de un valor de ranking.
+type RankingFacet struct {
+	RankingValue int    `bson:"_id" json:"ranking_value"`
+	RankingName  string `bson:"ranking_name" json:"ranking_name"`
+	Count        int    `bson:"count" json:"count"`
+}
+
+// Facets resume los resultados para armar los filtros del catálogo.
+type Facets struct {
+	Genres   []GenreFacet   `bson:"genres" json:"genres"`
+	Rankings []RankingFacet `bson:"rankings" json:"rankings"`
+}
+
+// SearchResult es una página de resultados de búsqueda. Las facetas cuentan
+// todos los resultados que cumplen los filtros, no solo los de la página.
+type SearchResult struct {
+	Movies   []models.ScoredMovie `json:"movies"`
+	Total    int                  `json:"total"`
+	Page     int                  `json:"page"`
+	PageSize int                  `json:"page_size"`
+	Mode     SearchMode           `json:"mode"`
+	Facets   Facets               `json:"facets"`
+}
+
+// Buscar películas por texto o género, ordenadas por relevancia
+func (r *MovieRepository) Search(ctx context.Context, query MovieQuery) (*SearchResult, error) {
+	mode := SearchAll
+	if textSearchTerms(query.Text) != "" {
+		mode = SearchText
+	}
+
+	result, err := r.search(ctx, query, mode)
+	if err != nil {
+		return nil, err
+	}
+	if mode == SearchText && result.Total == 0 {
+		return r.search(ctx, query, SearchPartial)
+	}
+	return result, nil
+}
+
+func (r *MovieRepository) search(ctx context.Context, query MovieQuery, mode SearchMode) (*SearchResult, error) {
+	pipeline := bson.A{
+		bson.M{"$match": query.filter(mode)},
+	}
+
+	sort := bson.D{{Key: "title", Value: 1}}
+	if mode == SearchText {
+		pipeline = append(pipeline, bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}})
+		sort = bson.D{{Key: "score", Value: -1}, {Key: "title", Value: 1}}
+	}
+
+	page := bson.A{bson.M{"$sort": sort}}
+	if query.PageSize > 0 {
+		page = append(page,
+			bson.M{"$skip": (max(query.Page, 1) - 1) * query.PageSize},
+			bson.M{"$limit": query.PageSize},
+		)
+	}
+	pipeline = append(pipeline, bson.M{"$facet": bson.M{
+		"movies": page,
+		"total":  bson.A{bson.M{"$count": "count"}},
+		"genres": bson.A{
+			bson.M{"$unwind": "$genre"},
+			bson.M{"$group": bson.M{
+				"_id":        "$genre.genre_id",
+				"genre_name": bson.M{"$first": "$genre.genre_name"},
+				"count":      bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		},
+		"rankings": bson.A{
+			bson.M{"$match": bson.M{"ranking.ranking_value": bson.M{"$gt": 0}}},
+			bson.M{"$group": bson.M{
+				"_id":          "$ranking.ranking_value",
+				"ranking_name": bson.M{"$first": "$ranking.ranking_name"},
+				"count":        bson.M{"$sum": 1},
+			}},
+			bson.M{"$sort": bson.M{"_id": 1}},
+		},
+	}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Movies []models.ScoredMovie `bson:"movies"`
+		Total  []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+		Facets `bson:",inline"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Movies:   []models.ScoredMovie{},
+		Page:     max(query.Page, 1),
+		PageSize: query.PageSize,
+		Mode:     mode,
+		Facets:   Facets{Genres: []GenreFacet{}, Rankings: []RankingFacet{}},
+	}
+	if len(facets) > 0 {
+		if facets[0].Genres != nil {
+			result.Facets.Genres = facets[0].Genres
+		}
+		if facets[0].Rankings != nil {
+			result.Facets.Rankings = facets[0].Rankings
+		}
+		if facets[0].Movies != nil {
+			result.Movies = facets[0].Movies
+		}
+		if len(facets[0].Total) > 0 {
+			result.Total = facets[0].Total[0].Count
+		}
+	}
+	return result, nil
+}
+
+// ForEach recorre las películas que cumplen la búsqueda sin cargarlas todas en memoria.
+func (r *MovieRepository) ForEach(ctx context.Context, query MovieQuery, fn func(models.Movie) error) error {
+	mode := SearchAll
+	if textSearchTerms(query.Text) != "" {
+		mode = SearchText
+		count, err := r.collection.CountDocuments(ctx, query.filter(mode))
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			mode = SearchPartial
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, query.filter(mode), options.Find().SetSort(bson.D{{Key: "imdb_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var movie models.Movie
+		if err := cursor.Decode(&movie); err != nil {
+			return err
+		}
+		if err := fn(movie); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (q MovieQuery) filter(mode SearchMode) bson.M {
+	filter := bson.M{}
+
+	switch mode {
+	case SearchText:
+		filter["$text"] = bson.M{
+			"$search":             textSearchTerms(q.Text),
+			"$language":           "spanish",
+			"$caseSensitive":      false,
+			"$diacriticSensitive": false,
+		}
+	case SearchPartial:
+		filter["title"] = bson.M{
+			"$regex":   accentInsensitivePattern(q.Text),
+			"$options": "i",
+		}
+	}
+
+	if conditions := q.genreConditions(); len(conditions) > 0 {
+		if q.GenreMatch == MatchAll {
+			filter["$and"] = conditions
+		} else {
+			filter["$or"] = conditions
+		}
+	}
+
+	if ranking := rangeFilter(q.RankingMin, q.RankingMax); ranking != nil {
+		filter["ranking.ranking_value"] = ranking
+	}
+	if year := rangeFilter(q.YearFrom, q.YearTo); year != nil {
+		filter["release_year"] = year
+	}
+
+	presenceFilter(filter, "admin_review", q.HasReview)
+	presenceFilter(filter, "youtube_id", q.HasTrailer)
+	presenceFilter(filter, "watch_url", q.HasWatchURL)
+
+	return filter
+}
+
+// genreConditions arma una condición por género: por id si es numérico o por
+// nombre (sin distinguir mayúsculas ni tildes) en otro caso.
+func (q MovieQuery) genreConditions() bson.A {
+	var conditions bson.A
+	for _, genre := range q.Genres {
+		if id, err := strconv.Atoi(genre); err == nil {
+			conditions = append(conditions, bson.M{"genre.genre_id": id})
+			continue
+		}
+		conditions = append(conditions, bson.M{"genre.genre_name": bson.M{
+			"$regex":   "^" + accentInsensitivePattern(genre) + "$",
+			"$options": "i",
+		}})
+	}
+	return conditions
+}
+
+// rangeFilter devuelve el filtro $gte/$lte para un rango. Cero no limita.
+func rangeFilter(from, to int) bson.M {
+	if from == 0 && to == 0 {
+		return nil
+	}
+	filter := bson.M{}
+	if from > 0 {
+		filter["$gte"] = from
+	}
+	if to > 0 {
+		filter["$lte"] = to
+	}
+	return filter
+}
+
+// presenceFilter filtra por campos de texto con o sin contenido.
+func presenceFilter(filter bson.M, field string, present *bool) {
+	if present == nil {
+		return
+	}
+	empty := bson.A{"", nil}
+	if *present {
+		filter[field] = bson.M{"$nin": empty}
+	} else {
+		filter[field] = bson.M{"$in": empty}
+	}
+}
+
+// textSearchTerms limpia el texto del usuario para $text: quita las comillas
+// (frases exactas) y los guiones iniciales (negación) para que la entrada se
+// interprete siempre como palabras sueltas.
+func textSearchTerms(text string) string {
+	text = strings.NewReplacer(`"`, " ", `\`, " ").Replace(text)
+	terms := strings.Fields(text)
+	for i, term := range terms {
+		terms[i] = strings.TrimLeft(term, "-")
+	}
+	return strings.Join(strings.Fields(strings.Join(terms, " ")), " ")
+}
+
+// Variantes con tilde de cada letra, para que "accion" coincida con "Acción"
+var accentVariants = map[rune]string{
+	'a': "aáàäâ",
+	'e': "eéèëê",
+	'i': "iíìïî",
+	'o': "oóòöô",
+	'u': "uúùüû",
+	'n': "nñ",
+	'c': "cç",
+}
+
+// accentInsensitivePattern escapa el texto del usuario y reemplaza cada letra
+// con tilde posible por una clase de caracteres con todas sus variantes.
+func accentInsensitivePattern(text string) string {
+	var pattern strings.Builder
+	for _, char := range strings.TrimSpace(text) {
+		base := baseLetter(char)
+		if variants, ok := accentVariants[base]; ok {
+			pattern.WriteString("[" + variants + strings.ToUpper(variants) + "]")
+			continue
+		}
+		pattern.WriteString(regexp.QuoteMeta(string(char)))
+	}
+	return pattern.String()
+}
+
+// baseLetter devuelve la letra minúscula sin tilde.
+func baseLetter(char rune) rune {
+	lower := []rune(strings.ToLower(string(char)))[0]
+	for base, variants := range accentVariants {
+		if strings.ContainsRune(variants, lower) {
+			return base
+		}
+	}
+	return lower
+}
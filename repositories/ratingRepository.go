@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// RatingRepository guarda las calificaciones de los usuarios y mantiene el
+// puntaje (user_score) de cada película.
+type RatingRepository struct {
+	ratings *mongo.Collection
+	movies  *mongo.Collection
+}
+
+func NewRatingRepository(db *mongo.Database) *RatingRepository {
+	return &RatingRepository{
+		ratings: db.Collection(database.RatingsCollection),
+		movies:  db.Collection(database.MoviesCollection),
+	}
+}
+
+// Find devuelve la calificación del usuario a la película, o ErrNotFound.
+func (r *RatingRepository) Find(ctx context.Context, userID, imdbID string) (*models.Rating, error) {
+	var rating models.Rating
+	err := r.ratings.FindOne(ctx, ratingFilter(userID, imdbID)).Decode(&rating)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+// Rate crea o cambia la calificación del usuario y ajusta el puntaje de la
+// película. Devuelve ErrNotFound si la película no existe.
+func (r *RatingRepository) Rate(ctx context.Context, userID, imdbID string, value int) (*models.Rating, error) {
+	if err := r.movies.FindOne(ctx, bson.D{{Key: "imdb_id", Value: imdbID}}).Err(); errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set":         bson.M{"rating": value, "updated_at": now},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	// El documento anterior indica si la calificación es nueva o cambia un valor.
+	var previous models.Rating
+	err := r.ratings.FindOneAndUpdate(ctx, ratingFilter(userID, imdbID), update, opts).Decode(&previous)
+	if mongo.IsDuplicateKeyError(err) {
+		// Otra primera calificación del mismo usuario ganó el upsert: ahora
+		// el documento existe y la actualización lo encuentra
+		err = r.ratings.FindOneAndUpdate(ctx, ratingFilter(userID, imdbID), update, opts).Decode(&previous)
+	}
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		err = r.applyScore(ctx, imdbID, value, 1)
+	case err == nil:
+		err = r.applyScore(ctx, imdbID, value-previous.Value, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Find(ctx, userID, imdbID)
+}
+
+// Delete borra la calificación del usuario y la descuenta del puntaje.
+func (r *RatingRepository) Delete(ctx context.Context, userID, imdbID string) error {
+	var previous models.Rating
+	err := r.ratings.FindOneAndDelete(ctx, ratingFilter(userID, imdbID)).Decode(&previous)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return r.applyScore(ctx, imdbID, -previous.Value, -1)
+}
+
+// applyScore suma los cambios a user_score y recalcula el promedio en una
+// sola actualización, así dos calificaciones simultáneas no se pisan.
+func (r *RatingRepository) applyScore(ctx context.Context, imdbID string, sumDelta, countDelta int) error {
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"user_score.sum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$user_score.sum", 0}}, sumDelta}},
+			"user_score.count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$user_score.count", 0}}, countDelta}},
+		}}},
+		averageStage,
+	}
+	_, err := r.movies.UpdateOne(ctx, bson.D{{Key: "imdb_id", Value: imdbID}}, update)
+	return err
+}
+
+// RecomputeScores recalcula user_score de todas las películas desde las
+// calificaciones guardadas. Es una reparación para puntajes que quedaron
+// desfasados (por ejemplo si el proceso cayó entre la calificación y el
+// puntaje); corre entera en el servidor y escribe cada película una sola vez.
+func (r *RatingRepository) RecomputeScores(ctx context.Context) error {
+	cursor, err := r.movies.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         database.RatingsCollection,
+			"localField":   "imdb_id",
+			"foreignField": "imdb_id",
+			"as":           "ratings",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"user_score.sum":   bson.M{"$sum": "$ratings.rating"},
+			"user_score.count": bson.M{"$size": "$ratings"},
+		}}},
+		averageStage,
+		{{Key: "$merge", Value: bson.M{
+			"into":           database.MoviesCollection,
+			"on":             "_id",
+			"whenMatched":    "merge",
+			"whenNotMatched": "discard",
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// averageStage calcula user_score.average con la suma y la cantidad.
+var averageStage = bson.D{{Key: "$set", Value: bson.M{
+	"user_score.average": bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$user_score.count", 0}},
+		bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$user_score.sum", "$user_score.count"}}, 2}},
+		0,
+	}},
+}}}
+
+func ratingFilter(userID, imdbID string) bson.D {
+	return bson.D{{Key: "user_id", Value: userID}, {Key: "imdb_id", Value: imdbID}}
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Peso de las interacciones según lo que hizo el usuario. Una calificación de
+// 6 a 10 va de 0,2 a 1; las de 5 o menos solo marcan la película como vista.
+const (
+	minLikedRating   = 6
+	finishedWeight   = 0.8
+	inProgressWeight = 0.3
+)
+
+// RecommendationRepository lee las calificaciones y el historial como
+// interacciones y guarda las películas vecinas precalculadas.
+type RecommendationRepository struct {
+	ratings    *mongo.Collection
+	watch      *mongo.Collection
+	neighbours *mongo.Collection
+}
+
+func NewRecommendationRepository(db *mongo.Database) *RecommendationRepository {
+	return &RecommendationRepository{
+		ratings:    db.Collection(database.RatingsCollection),
+		watch:      db.Collection(database.WatchHistoryCollection),
+		neighbours: db.Collection(database.NeighboursCollection),
+	}
+}
+
+// Interactions devuelve una interacción por usuario y película, con el mayor
+// peso entre su calificación y su historial. Con userID vacío devuelve las de
+// todos los usuarios.
+func (r *RecommendationRepository) Interactions(ctx context.Context, userID string) ([]models.Interaction, error) {
+	filter := bson.D{}
+	if userID != "" {
+		filter = bson.D{{Key: "user_id", Value: userID}}
+	}
+
+	type key struct{ user, movie string }
+	weights := map[key]float64{}
+	add := func(user, movie string, weight float64) {
+		k := key{user, movie}
+		if current, ok := weights[k]; !ok || weight > current {
+			weights[k] = weight
+		}
+	}
+
+	ratings, err := r.ratings.Find(ctx, filter, options.Find().SetProjection(bson.M{"user_id": 1, "imdb_id": 1, "rating": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer ratings.Close(ctx)
+	for ratings.Next(ctx) {
+		var rating models.Rating
+		if err := ratings.Decode(&rating); err != nil {
+			return nil, err
+		}
+		weight := 0.0
+		if rating.Value >= minLikedRating {
+			weight = float64(rating.Value-minLikedRating+1) / float64(10-minLikedRating+1)
+		}
+		add(rating.UserID, rating.ImdbID, weight)
+	}
+	if err := ratings.Err(); err != nil {
+		return nil, err
+	}
+
+	entries, err := r.watch.Find(ctx, filter, options.Find().SetProjection(bson.M{"user_id": 1, "imdb_id": 1, "finish_count": 1, "position_seconds": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer entries.Close(ctx)
+	for entries.Next(ctx) {
+		var entry models.WatchEntry
+		if err := entries.Decode(&entry); err != nil {
+			return nil, err
+		}
+		switch {
+		case entry.FinishCount > 0:
+			add(entry.UserID, entry.ImdbID, finishedWeight)
+		case entry.PositionSeconds > 0:
+			add(entry.UserID, entry.ImdbID, inProgressWeight)
+		}
+	}
+	if err := entries.Err(); err != nil {
+		return nil, err
+	}
+
+	interactions := make([]models.Interaction, 0, len(weights))
+	for k, weight := range weights {
+		interactions = append(interactions, models.Interaction{UserID: k.user, ImdbID: k.movie, Weight: weight})
+	}
+	return interactions, nil
+}
+
+// ReplaceNeighbours guarda las vecinas calculadas en builtAt y borra las de
+// cálculos anteriores.
+func (r *RecommendationRepository) ReplaceNeighbours(ctx context.Context, neighbours map[string][]models.Neighbour, builtAt time.Time) error {
+	if len(neighbours) > 0 {
+		writes := make([]mongo.WriteModel, 0, len(neighbours))
+		for imdbID, list := range neighbours {
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.D{{Key: "imdb_id", Value: imdbID}}).
+				SetReplacement(models.MovieNeighbours{ImdbID: imdbID, Neighbours: list, BuiltAt: builtAt}).
+				SetUpsert(true))
+		}
+		if _, err := r.neighbours.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.neighbours.DeleteMany(ctx, bson.M{"built_at": bson.M{"$lt": builtAt}})
+	return err
+}
+
+// Neighbours devuelve las vecinas guardadas de las películas indicadas, por imdb_id.
+func (r *RecommendationRepository) Neighbours(ctx context.Context, imdbIDs []string) (map[string][]models.Neighbour, error) {
+	cursor, err := r.neighbours.Find(ctx, bson.M{"imdb_id": bson.M{"$in": imdbIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	neighbours := map[string][]models.Neighbour{}
+	for cursor.Next(ctx) {
+		var doc models.MovieNeighbours
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		neighbours[doc.ImdbID] = doc.Neighbours
+	}
+	return neighbours, cursor.Err()
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "errors"
+
+// ErrNotFound se devuelve cuando el documento buscado no existe.
+var ErrNotFound = errors.New("documento no encontrado")
+
+// ErrDuplicate se devuelve cuando un índice único rechaza el documento.
+var ErrDuplicate = errors.New("documento duplicado")
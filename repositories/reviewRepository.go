@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ReviewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReviewRepository(db *mongo.Database) *ReviewRepository {
+	return &ReviewRepository{collection: db.Collection(database.ReviewsCollection)}
+}
+
+// ReviewQuery filtra un listado de reseñas. Los campos vacíos no filtran.
+type ReviewQuery struct {
+	ImdbID   string
+	UserID   string
+	Statuses []models.ReviewStatus
+	Page     int
+	PageSize int
+}
+
+// ReviewPage es una página de reseñas, de la más nueva a la más vieja.
+type ReviewPage struct {
+	Reviews  []models.Review `json:"reviews"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// Find devuelve una página de reseñas que cumplen el filtro.
+func (r *ReviewRepository) Find(ctx context.Context, query ReviewQuery) (*ReviewPage, error) {
+	filter := bson.D{}
+	if query.ImdbID != "" {
+		filter = append(filter, bson.E{Key: "imdb_id", Value: query.ImdbID})
+	}
+	if query.UserID != "" {
+		filter = append(filter, bson.E{Key: "user_id", Value: query.UserID})
+	}
+	if len(query.Statuses) > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: bson.M{"$in": query.Statuses}})
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((query.Page - 1) * query.PageSize)).
+		SetLimit(int64(query.PageSize))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reviews := []models.Review{}
+	if err := cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return &ReviewPage{Reviews: reviews, Total: total, Page: query.Page, PageSize: query.PageSize}, nil
+}
+
+// FindByID devuelve una reseña o ErrNotFound.
+func (r *ReviewRepository) FindByID(ctx context.Context, id bson.ObjectID) (*models.Review, error) {
+	var review models.Review
+	err := r.collection.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&review)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// Insert guarda una reseña nueva. Devuelve ErrDuplicate si el usuario ya
+// escribió una reseña de la película.
+func (r *ReviewRepository) Insert(ctx context.Context, review models.Review) (*models.Review, error) {
+	now := time.Now()
+	review.ID = bson.NewObjectID()
+	review.CreatedAt, review.UpdatedAt = now, now
+
+	_, err := r.collection.InsertOne(ctx, review)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicate
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// UpdateText cambia el texto de una reseña con el estado y los motivos de la
+// nueva revisión automática.
+func (r *ReviewRepository) UpdateText(ctx context.Context, id bson.ObjectID, text string, status models.ReviewStatus, flags []string) (*models.Review, error) {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{"text": text, "status": status, "flags": flags, "updated_at": time.Now()},
+	})
+}
+
+// Moderate cambia el estado de una reseña y registra quién lo hizo.
+func (r *ReviewRepository) Moderate(ctx context.Context, id bson.ObjectID, status models.ReviewStatus, note, adminID string) (*models.Review, error) {
+	return r.update(ctx, id, bson.M{
+		"$set": bson.M{
+			"status":          status,
+			"moderation_note": note,
+			"moderated_by":    adminID,
+			"moderated_at":    time.Now(),
+		},
+	})
+}
+
+// Delete borra una reseña. Devuelve ErrNotFound si no existe.
+func (r *ReviewRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *ReviewRepository) update(ctx context.Context, id bson.ObjectID, update bson.M) (*models.Review, error) {
+	var review models.Review
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := r.collection.FindOneAndUpdate(ctx, bson.D{{Key: "_id", Value: id}}, update, opts).Decode(&review)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type UserRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) *UserRepository {
+	return &UserRepository{collection: db.Collection(database.UsersCollection)}
+}
+
+// Inserta un usuario. El índice único de email devuelve ErrDuplicate si ya existe.
+func (r *UserRepository) Insert(ctx context.Context, user models.User) (*mongo.InsertOneResult, error) {
+	result, err := r.collection.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicate
+	}
+	return result, err
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.findOne(ctx, bson.D{{Key: "email", Value: email}})
+}
+
+func (r *UserRepository) FindByUserID(ctx context.Context, userID string) (*models.User, error) {
+	return r.findOne(ctx, bson.D{{Key: "user_id", Value: userID}})
+}
+
+// FavouriteGenres devuelve los géneros favoritos de cada usuario, por user_id.
+func (r *UserRepository) FavouriteGenres(ctx context.Context) (map[string][]models.Genre, error) {
+	projection := bson.M{"user_id": 1, "favourite_genres": 1}
+	cursor, err := r.collection.Find(ctx, bson.D{}, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	favourites := map[string][]models.Genre{}
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return nil, err
+		}
+		favourites[user.UserID] = user.FavouriteGenres
+	}
+	return favourites, cursor.Err()
+}
+
+// Actualiza tokens en la base de datos
+func (r *UserRepository) UpdateTokens(ctx context.Context, userID, token, refreshToken string) error {
+	updateData := bson.M{
+		"$set": bson.M{
+			"token":         token,
+			"refresh_token": refreshToken,
+			"updated_at":    time.Now(),
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"user_id": userID}, updateData)
+	return err
+}
+
+func (r *UserRepository) findOne(ctx context.Context, filter bson.D) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Proporción vista a partir de la cual un avance cuenta como película terminada
+const finishedShare = 0.95
+
+// WatchRepository guarda el historial de reproducción de los usuarios.
+type WatchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWatchRepository(db *mongo.Database) *WatchRepository {
+	return &WatchRepository{collection: db.Collection(database.WatchHistoryCollection)}
+}
+
+// Record aplica un evento del reproductor a la entrada del usuario y la
+// película, creándola si no existe. Un avance que llega al 95% de la duración
+// cuenta como terminada.
+func (r *WatchRepository) Record(ctx context.Context, userID, imdbID string, event models.PlaybackEvent, position, duration int) (*models.WatchEntry, error) {
+	if event == models.PlaybackProgress && duration > 0 && float64(position) >= finishedShare*float64(duration) {
+		event = models.PlaybackFinished
+	}
+
+	now := time.Now()
+	var storedDuration any = bson.M{"$ifNull": bson.A{"$duration_seconds", 0}}
+	if duration > 0 {
+		storedDuration = duration
+	}
+
+	// La primera etapa completa los valores de una entrada nueva; la segunda
+	// aplica el evento. Dentro de una etapa las expresiones leen los valores
+	// anteriores, así "terminada" se cuenta una sola vez.
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"started_at":       bson.M{"$ifNull": bson.A{"$started_at", now}},
+			"play_count":       bson.M{"$ifNull": bson.A{"$play_count", 0}},
+			"finish_count":     bson.M{"$ifNull": bson.A{"$finish_count", 0}},
+			"duration_seconds": storedDuration,
+			"last_watched_at":  now,
+		}}},
+	}
+
+	switch event {
+	case models.PlaybackStarted:
+		pipeline = append(pipeline, bson.D{{Key: "$set", Value: bson.M{
+			"status":           models.WatchInProgress,
+			"position_seconds": position,
+			"play_count":       bson.M{"$add": bson.A{"$play_count", 1}},
+			"started_at":       now,
+		}}})
+	case models.PlaybackProgress:
+		pipeline = append(pipeline, bson.D{{Key: "$set", Value: bson.M{
+			"status":           models.WatchInProgress,
+			"position_seconds": position,
+			"play_count":       bson.M{"$max": bson.A{"$play_count", 1}},
+		}}})
+	case models.PlaybackFinished:
+		pipeline = append(pipeline, bson.D{{Key: "$set", Value: bson.M{
+			"status":           models.WatchFinished,
+			"position_seconds": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$duration_seconds", 0}}, "$duration_seconds", position}},
+			"play_count":       bson.M{"$max": bson.A{"$play_count", 1}},
+			"finish_count": bson.M{"$add": bson.A{"$finish_count",
+				bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", models.WatchFinished}}, 0, 1}},
+			}},
+			"finished_at": now,
+		}}})
+	}
+
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "imdb_id", Value: imdbID}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var entry models.WatchEntry
+	err := r.collection.FindOneAndUpdate(ctx, filter, pipeline, opts).Decode(&entry)
+	if mongo.IsDuplicateKeyError(err) {
+		// Otro primer evento de la misma película ganó el upsert: ahora el
+		// documento existe y la actualización lo encuentra
+		err = r.collection.FindOneAndUpdate(ctx, filter, pipeline, opts).Decode(&entry)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// WatchHistoryPage es una página del historial, de lo último visto a lo más viejo.
+type WatchHistoryPage struct {
+	Entries  []models.WatchEntry `json:"entries"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+}
+
+// History devuelve una página del historial del usuario con los datos de cada película.
+func (r *WatchRepository) History(ctx context.Context, userID string, page, pageSize int) (*WatchHistoryPage, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := r.findWithMovies(ctx, filter, int64((page-1)*pageSize), int64(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	return &WatchHistoryPage{Entries: entries, Total: total, Page: page, PageSize: pageSize}, nil
+}
+
+// ContinueWatching devuelve las películas empezadas y sin terminar, la
+// última vista primero.
+func (r *WatchRepository) ContinueWatching(ctx context.Context, userID string, limit int) ([]models.WatchEntry, error) {
+	filter := bson.D{
+		{Key: "user_id", Value: userID},
+		{Key: "status", Value: models.WatchInProgress},
+		{Key: "position_seconds", Value: bson.M{"$gt": 0}},
+	}
+	return r.findWithMovies(ctx, filter, 0, int64(limit))
+}
+
+// FinishedIDs devuelve los imdb_id de las películas que el usuario terminó de ver.
+func (r *WatchRepository) FinishedIDs(ctx context.Context, userID string) (map[string]bool, error) {
+	filter := bson.D{{Key: "user_id", Value: userID}, {Key: "finish_count", Value: bson.M{"$gt": 0}}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"imdb_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	finished := map[string]bool{}
+	for cursor.Next(ctx) {
+		var entry models.WatchEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		finished[entry.ImdbID] = true
+	}
+	return finished, cursor.Err()
+}
+
+// Delete quita una película del historial del usuario.
+func (r *WatchRepository) Delete(ctx context.Context, userID, imdbID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.D{{Key: "user_id", Value: userID}, {Key: "imdb_id", Value: imdbID}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// findWithMovies busca entradas del historial, de la última vista a la más
+// vieja, y les agrega el título y el póster de la película.
+func (r *WatchRepository) findWithMovies(ctx context.Context, filter bson.D, skip, limit int64) ([]models.WatchEntry, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "last_watched_at", Value: -1}}}},
+		{{Key: "$skip", Value: skip}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         database.MoviesCollection,
+			"localField":   "imdb_id",
+			"foreignField": "imdb_id",
+			"as":           "movie",
+			"pipeline":     bson.A{bson.M{"$project": bson.M{"_id": 0, "imdb_id": 1, "title": 1, "poster_path": 1}}},
+		}}},
+		{{Key: "$set", Value: bson.M{"movie": bson.M{"$first": "$movie"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []models.WatchEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, bulk *controller.BulkController, genres *controller.GenreController, reviews *controller.ReviewController) {
+
+	admin := router.Group("/admin")
+	admin.Use(auth, middleware.RequireRole("ADMIN"))
+	admin.POST("/movies/import", bulk.ImportMovies())
+	admin.GET("/movies/export", bulk.ExportMovies())
+	admin.POST("/genres", genres.AddGenre())
+	admin.GET("/genres/:genre_id", genres.GetGenre())
+	admin.PATCH("/genres/:genre_id", genres.UpdateGenre())
+	admin.DELETE("/genres/:genre_id", genres.DeleteGenre())
+	admin.GET("/reviews", reviews.ModerationQueue())
+	admin.PATCH("/reviews/:review_id", reviews.ModerateReview())
+}
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Fecha desde la que las rutas anteriores a /api/v1 están obsoletas
+var LegacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// Alias es una ruta anterior a /api/v1 que cambió de nombre en la versión 1.
+type Alias struct {
+	Method string
+	Path   string
+	// Reemplazo dentro de /api/v1
+	NewMethod string
+	NewPath   string
+}
+
+// LegacyRenames son las rutas anteriores a /api/v1 que cambiaron de nombre.
+// Las demás conservan el método y la ruta dentro de /api/v1.
+var LegacyRenames = []Alias{
+	{http.MethodGet, "/movie/:imdb_id", http.MethodGet, "/movies/:imdb_id"},
+	{http.MethodGet, "/movie/:imdb_id/similar", http.MethodGet, "/movies/:imdb_id/similar"},
+	{http.MethodPost, "/register", http.MethodPost, "/auth/register"},
+	{http.MethodPost, "/login", http.MethodPost, "/auth/login"},
+	{http.MethodPost, "/logout", http.MethodPost, "/auth/logout"},
+	{http.MethodPost, "/refresh", http.MethodPost, "/auth/refresh"},
+	{http.MethodPost, "/addmovie", http.MethodPost, "/movies"},
+	{http.MethodPatch, "/updatereview/:imdb_id", http.MethodPut, "/movies/:imdb_id/admin-review"},
+	{http.MethodGet, "/movie/:imdb_id/review/history", http.MethodGet, "/movies/:imdb_id/admin-review/history"},
+	{http.MethodPost, "/movie/:imdb_id/review/revert", http.MethodPost, "/movies/:imdb_id/admin-review/revert"},
+	{http.MethodPut, "/movie/:imdb_id/rating", http.MethodPut, "/movies/:imdb_id/rating"},
+	{http.MethodDelete, "/movie/:imdb_id/rating", http.MethodDelete, "/movies/:imdb_id/rating"},
+	{http.MethodGet, "/movie/:imdb_id/reviews", http.MethodGet, "/movies/:imdb_id/reviews"},
+	{http.MethodPost, "/movie/:imdb_id/reviews", http.MethodPost, "/movies/:imdb_id/reviews"},
+}
+
+// LegacySuccessor devuelve el método y la ruta que reemplazan en /api/v1 a
+// una ruta anterior.
+func LegacySuccessor(method, path string) (string, string) {
+	for _, alias := range LegacyRenames {
+		if alias.Method == method && alias.Path == path {
+			return alias.NewMethod, alias.NewPath
+		}
+	}
+	return method, path
+}
+
+// LegacyRoute es la inversa de LegacySuccessor: la ruta anterior a /api/v1
+// que corresponde a una ruta de la versión 1.
+func LegacyRoute(method, path string) (string, string) {
+	for _, alias := range LegacyRenames {
+		if alias.NewMethod == method && alias.NewPath == path {
+			return alias.Method, alias.Path
+		}
+	}
+	return method, path
+}
+
+// SetupLegacyRoutes registra en la raíz las rutas anteriores a /api/v1 para
+// no romper a los clientes ya desplegados. Usan los mismos handlers que la
+// versión 1 y agregan los encabezados Deprecation, Sunset y Link; se quitan
+// después de sunset.
+func SetupLegacyRoutes(router *gin.Engine, h Handlers, sunset time.Time) {
+	legacy := router.Group("", middleware.Deprecated(LegacyDeprecatedAt, sunset, func(method, route string) string {
+		_, path := LegacySuccessor(method, route)
+		return V1Prefix + path
+	}))
+
+	legacy.GET("/movies", h.Identify, h.Movies.GetMovies())
+	legacy.GET("/movies/trending", h.Identify, h.Movies.TrendingMovies())
+	legacy.GET("/movie/:imdb_id", h.Identify, h.Movies.GetMovie())
+	legacy.GET("/movie/:imdb_id/similar", h.Identify, h.Movies.SimilarMovies())
+	legacy.GET("/genres", h.Genres.GetGenres())
+	legacy.GET("/search", h.Identify, h.Movies.SearchMovies())
+	legacy.GET("/search/suggest", h.Movies.SuggestMovies())
+	legacy.POST("/register", h.Users.RegisterUser())
+	legacy.POST("/login", h.Users.LoginUser())
+	legacy.POST("/logout", h.Users.LogoutHandler())
+	legacy.POST("/refresh", h.Users.RefreshTokenHandler())
+	legacy.GET("/movie/:imdb_id/reviews", h.Reviews.MovieReviews())
+
+	protected := legacy.Group("")
+	protected.Use(h.Auth)
+	protected.POST("/addmovie", h.Movies.AddMovie())
+	protected.PATCH("/updatereview/:imdb_id", h.AdminReviews.AdminReview())
+	protected.GET("/movie/:imdb_id/review/history", middleware.RequireRole("ADMIN"), h.AdminReviews.ReviewHistory())
+	protected.POST("/movie/:imdb_id/review/revert", middleware.RequireRole("ADMIN"), h.AdminReviews.RevertReview())
+	protected.PUT("/movie/:imdb_id/rating", h.Ratings.RateMovie())
+	protected.DELETE("/movie/:imdb_id/rating", h.Ratings.DeleteRating())
+	protected.POST("/movie/:imdb_id/reviews", h.Reviews.CreateReview())
+
+	// Rutas que no cambiaron de nombre
+	legacy.GET("/users/:user_id/reviews", h.Reviews.UserReviews())
+	protected.PATCH("/reviews/:review_id", h.Reviews.UpdateReview())
+	protected.DELETE("/reviews/:review_id", h.Reviews.DeleteReview())
+	protected.GET("/me/reviews", h.Reviews.MyReviews())
+	SetupListRoutes(legacy, h.Auth, h.Lists)
+	SetupWatchRoutes(legacy, h.Auth, h.Watch)
+	SetupRecommendationRoutes(legacy, h.Auth, h.Recommendations)
+	SetupAdminRoutes(legacy, h.Auth, h.Bulk, h.Genres, h.Reviews)
+}
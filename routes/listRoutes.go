@@ -0,0 +1,22 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupListRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, lists *controller.ListController) {
+
+	router.GET("/lists/:slug", lists.PublicList())
+
+	me := router.Group("/me/lists")
+	me.Use(auth)
+	me.GET("", lists.MyLists())
+	me.POST("", lists.CreateList())
+	me.GET("/:list_id", lists.GetList())
+	me.PATCH("/:list_id", lists.UpdateList())
+	me.DELETE("/:list_id", lists.DeleteList())
+	me.PUT("/:list_id/order", lists.ReorderList())
+	me.POST("/:list_id/movies", lists.AddMovie())
+	me.DELETE("/:list_id/movies/:imdb_id", lists.RemoveMovie())
+}
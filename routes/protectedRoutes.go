@@ -4,13 +4,16 @@ import (
 	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
 	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/middleware"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
-func SetupProtectedRoutes(router *gin.Engine, client *mongo.Client) {
+func SetupProtectedRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, movies *controller.MovieController, ratings *controller.RatingController, adminReviews *controller.AdminReviewController) {
 
-	protected := router.Group("/")
-	protected.Use(middleware.AuthMiddleWare())
-	protected.POST("/addmovie", controller.AddMovie(client))
-	protected.PATCH("/updatereview/:imdb_id", controller.AdminReview(client))
+	protected := router.Group("")
+	protected.Use(auth)
+	protected.POST("/movies", movies.AddMovie())
+	protected.PUT("/movies/:imdb_id/admin-review", adminReviews.AdminReview())
+	protected.GET("/movies/:imdb_id/admin-review/history", middleware.RequireRole("ADMIN"), adminReviews.ReviewHistory())
+	protected.POST("/movies/:imdb_id/admin-review/revert", middleware.RequireRole("ADMIN"), adminReviews.RevertReview())
+	protected.PUT("/movies/:imdb_id/rating", ratings.RateMovie())
+	protected.DELETE("/movies/:imdb_id/rating", ratings.DeleteRating())
 }
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRecommendationRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, recommendations *controller.RecommendationController) {
+
+	router.GET("/me/recommendations", auth, recommendations.MyRecommendations())
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReviewRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, reviews *controller.ReviewController) {
+
+	router.GET("/movies/:imdb_id/reviews", reviews.MovieReviews())
+	router.GET("/users/:user_id/reviews", reviews.UserReviews())
+
+	protected := router.Group("")
+	protected.Use(auth)
+	protected.POST("/movies/:imdb_id/reviews", reviews.CreateReview())
+	protected.PATCH("/reviews/:review_id", reviews.UpdateReview())
+	protected.DELETE("/reviews/:review_id", reviews.DeleteReview())
+	protected.GET("/me/reviews", reviews.MyReviews())
+}
@@ -3,17 +3,22 @@ package routes
 import (
 	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
-func SetupUnProtectedRoutes(router *gin.Engine, client *mongo.Client) {
+// identify reconoce al usuario si tiene sesión, sin exigirla.
+func SetupUnProtectedRoutes(router *gin.RouterGroup, identify gin.HandlerFunc, movies *controller.MovieController, genres *controller.GenreController, users *controller.UserController) {
 
-	router.GET("/movies", controller.GetMovies(client))
-	router.GET("/movie/:imdb_id", controller.GetMovie(client))
-	router.GET("/genres", controller.GetGenres(client))
-	router.GET("/search", controller.SearchMovies(client))
-	router.POST("/register", controller.RegisterUser(client))
-	router.POST("/login", controller.LoginUser(client))
-	router.POST("/logout", controller.LogoutHandler(client))
-	router.POST("/refresh", controller.RefreshTokenHandler(client))
+	router.GET("/movies", identify, movies.GetMovies())
+	router.GET("/movies/trending", identify, movies.TrendingMovies())
+	router.GET("/movies/:imdb_id", identify, movies.GetMovie())
+	router.GET("/movies/:imdb_id/similar", identify, movies.SimilarMovies())
+	router.GET("/genres", genres.GetGenres())
+	router.GET("/search", identify, movies.SearchMovies())
+	router.GET("/search/suggest", movies.SuggestMovies())
+
+	auth := router.Group("/auth")
+	auth.POST("/register", users.RegisterUser())
+	auth.POST("/login", users.LoginUser())
+	auth.POST("/logout", users.LogoutHandler())
+	auth.POST("/refresh", users.RefreshTokenHandler())
 }
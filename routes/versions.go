@@ -0,0 +1,42 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+// Prefijo de la versión 1 de la API
+const V1Prefix = "/api/v1"
+
+// Handlers reúne los controladores y el middleware de sesión que usan las
+// rutas, para registrarlos en cada versión de la API.
+type Handlers struct {
+	// Exige la sesión
+	Auth gin.HandlerFunc
+	// Reconoce la sesión si la hay, sin exigirla
+	Identify gin.HandlerFunc
+
+	Movies          *controller.MovieController
+	Genres          *controller.GenreController
+	Users           *controller.UserController
+	Bulk            *controller.BulkController
+	Ratings         *controller.RatingController
+	Reviews         *controller.ReviewController
+	AdminReviews    *controller.AdminReviewController
+	Lists           *controller.ListController
+	Watch           *controller.WatchController
+	Recommendations *controller.RecommendationController
+}
+
+// SetupV1 registra la versión 1 de la API bajo /api/v1. Una versión nueva va
+// en su propio grupo (/api/v2) y reusa los Setup*Routes que no cambien.
+func SetupV1(router *gin.Engine, h Handlers) {
+	v1 := router.Group(V1Prefix)
+	SetupUnProtectedRoutes(v1, h.Identify, h.Movies, h.Genres, h.Users)
+	SetupProtectedRoutes(v1, h.Auth, h.Movies, h.Ratings, h.AdminReviews)
+	SetupReviewRoutes(v1, h.Auth, h.Reviews)
+	SetupListRoutes(v1, h.Auth, h.Lists)
+	SetupWatchRoutes(v1, h.Auth, h.Watch)
+	SetupRecommendationRoutes(v1, h.Auth, h.Recommendations)
+	SetupAdminRoutes(v1, h.Auth, h.Bulk, h.Genres, h.Reviews)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWatchRoutes(router *gin.RouterGroup, auth gin.HandlerFunc, watch *controller.WatchController) {
+
+	me := router.Group("/me")
+	me.Use(auth)
+	me.POST("/playback", watch.RecordPlayback())
+	me.GET("/history", watch.History())
+	me.DELETE("/history/:imdb_id", watch.DeleteHistoryEntry())
+	me.GET("/continue-watching", watch.ContinueWatching())
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/config"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/seed"
+)
+
+func runSeed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	dir := flags.String("dir", seed.DefaultDir, "directorio con los archivos JSON")
+	dryRun := flags.Bool("dry-run", false, "solo valida los registros, sin escribir")
+	reset := flags.Bool("reset", false, "borra géneros, películas y usuarios antes de cargar")
+	testDocs := flags.Bool("test-docs", false, "incluye AddTestMovieDoc.json y AddTestUserDoc.json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer disconnect(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := seed.Run(ctx, client.Database(cfg.DatabaseName), seed.Options{
+		Dir:      *dir,
+		DryRun:   *dryRun,
+		Reset:    *reset,
+		TestDocs: *testDocs,
+	})
+	if report != nil {
+		printSeedReport(report, *dryRun)
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d registros con errores", len(report.Errors))
+	}
+	return nil
+}
+
+func printSeedReport(report *seed.Report, dryRun bool) {
+	if dryRun {
+		fmt.Println("Modo dry-run: no se escribió nada en la base de datos")
+	}
+	fmt.Printf("%-8s %7s %9s %11s %9s %7s\n", "", "válidos", "insertados", "actualizados", "sin cambio", "errores")
+	for _, row := range []struct {
+		name   string
+		counts seed.Counts
+	}{
+		{"géneros", report.Genres},
+		{"películas", report.Movies},
+		{"usuarios", report.Users},
+	} {
+		c := row.counts
+		fmt.Printf("%-8s %7d %9d %11d %9d %7d\n", row.name, c.Valid, c.Inserted, c.Updated, c.Unchanged, c.Failed)
+	}
+	for _, recordErr := range report.Errors {
+		fmt.Println("  -", recordErr.Error())
+	}
+}
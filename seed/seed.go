@@ -0,0 +1,291 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	controller "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/controllers"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Directorio por defecto de los archivos de datos
+const DefaultDir = "json files"
+
+// Options controla la ejecución del seed.
+type Options struct {
+	// Directorio con genres.json, movies.json y users.json
+	Dir string
+	// Solo valida los registros, sin escribir en la base de datos
+	DryRun bool
+	// Borra los documentos existentes antes de cargar
+	Reset bool
+	// Incluye AddTestMovieDoc.json y AddTestUserDoc.json
+	TestDocs bool
+}
+
+// Counts resume el resultado de una colección.
+type Counts struct {
+	Valid     int `json:"valid"`
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// RecordError describe un registro que no se pudo cargar.
+type RecordError struct {
+	File  string `json:"file"`
+	Index int    `json:"index"`
+	Key   string `json:"key"`
+	Err   error  `json:"-"`
+}
+
+func (e RecordError) Error() string {
+	return fmt.Sprintf("%s[%d] (%s): %v", e.File, e.Index, e.Key, e.Err)
+}
+
+// Report es el resultado completo del seed.
+type Report struct {
+	Genres Counts        `json:"genres"`
+	Movies Counts        `json:"movies"`
+	Users  Counts        `json:"users"`
+	Errors []RecordError `json:"errors"`
+}
+
+type seeder struct {
+	db     *mongo.Database
+	opts   Options
+	report *Report
+	// Géneros válidos del archivo, para validar las referencias de películas y usuarios
+	catalog models.GenreCatalog
+}
+
+// Run carga los archivos de datos en la base de datos. Los registros se
+// insertan o actualizan por su clave natural, por lo que puede ejecutarse
+// varias veces sin duplicar documentos.
+func Run(ctx context.Context, db *mongo.Database, opts Options) (*Report, error) {
+	if opts.Dir == "" {
+		opts.Dir = DefaultDir
+	}
+
+	s := &seeder{db: db, opts: opts, report: &Report{}, catalog: models.GenreCatalog{}}
+
+	if opts.Reset && !opts.DryRun {
+		for _, name := range []string{database.GenresCollection, database.MoviesCollection, database.UsersCollection} {
+			if _, err := db.Collection(name).DeleteMany(ctx, bson.D{}); err != nil {
+				return nil, fmt.Errorf("no se pudo vaciar la colección %s: %w", name, err)
+			}
+		}
+	}
+
+	if err := s.seedGenres(ctx, "genres.json"); err != nil {
+		return s.report, err
+	}
+
+	movieFiles := []string{"movies.json"}
+	userFiles := []string{"users.json"}
+	if opts.TestDocs {
+		movieFiles = append(movieFiles, "AddTestMovieDoc.json")
+		userFiles = append(userFiles, "AddTestUserDoc.json")
+	}
+	for _, file := range movieFiles {
+		if err := s.seedMovies(ctx, file); err != nil {
+			return s.report, err
+		}
+	}
+	for _, file := range userFiles {
+		if err := s.seedUsers(ctx, file); err != nil {
+			return s.report, err
+		}
+	}
+
+	return s.report, nil
+}
+
+func (s *seeder) seedGenres(ctx context.Context, file string) error {
+	var genres []models.Genre
+	if err := readRecords(filepath.Join(s.opts.Dir, file), &genres); err != nil {
+		return err
+	}
+
+	collection := s.db.Collection(database.GenresCollection)
+	for i, genre := range genres {
+		key := fmt.Sprint(genre.GenreID)
+		filter := bson.D{{Key: "genre_id", Value: genre.GenreID}}
+		update := bson.M{"$set": genre}
+		if s.apply(ctx, collection, &s.report.Genres, file, i, key, genre, filter, update) {
+			s.catalog[genre.GenreID] = genre
+		}
+	}
+	return nil
+}
+
+func (s *seeder) seedMovies(ctx context.Context, file string) error {
+	var movies []models.Movie
+	if err := readRecords(filepath.Join(s.opts.Dir, file), &movies); err != nil {
+		return err
+	}
+
+	collection := s.db.Collection(database.MoviesCollection)
+	for i, movie := range movies {
+		movie.ID = bson.ObjectID{}
+		movie.UserScore = nil
+		movie.Popularity = nil
+		if err := models.Validate(movie); err != nil {
+			s.fail(&s.report.Movies, file, i, movie.ImdbID, err)
+			continue
+		}
+		var err error
+		if movie.Genre, err = s.catalog.Resolve(movie.Genre); err != nil {
+			s.fail(&s.report.Movies, file, i, movie.ImdbID, err)
+			continue
+		}
+		// Volver a correr el seed no pisa la reseña ni los campos editados
+		filter := bson.D{{Key: "imdb_id", Value: movie.ImdbID}}
+		s.write(ctx, collection, &s.report.Movies, file, i, movie.ImdbID, filter, repositories.MovieUpsert(movie))
+	}
+	return nil
+}
+
+func (s *seeder) seedUsers(ctx context.Context, file string) error {
+	var users []models.User
+	if err := readRecords(filepath.Join(s.opts.Dir, file), &users); err != nil {
+		return err
+	}
+
+	collection := s.db.Collection(database.UsersCollection)
+	for i, user := range users {
+		if err := models.Validate(user); err != nil {
+			s.fail(&s.report.Users, file, i, user.Email, err)
+			continue
+		}
+		favourites, err := s.catalog.Resolve(user.FavouriteGenres)
+		if err != nil {
+			s.fail(&s.report.Users, file, i, user.Email, err)
+			continue
+		}
+
+		password := user.Password
+		if !isBcryptHash(password) {
+			hashed, err := controller.HashPassword(password)
+			if err != nil {
+				s.fail(&s.report.Users, file, i, user.Email, err)
+				continue
+			}
+			password = hashed
+		}
+
+		userID := user.UserID
+		if userID == "" {
+			userID = bson.NewObjectID().Hex()
+		}
+		createdAt := user.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		// La contraseña, el id y la fecha de creación solo se escriben al
+		// insertar, para no pisar datos de usuarios que ya existen.
+		filter := bson.D{{Key: "email", Value: user.Email}}
+		update := bson.M{
+			"$set": bson.M{
+				"first_name":       user.FirstName,
+				"last_name":        user.LastName,
+				"role":             user.Role,
+				"favourite_genres": favourites,
+			},
+			"$setOnInsert": bson.M{
+				"user_id":       userID,
+				"email":         user.Email,
+				"password":      password,
+				"created_at":    createdAt,
+				"updated_at":    time.Now(),
+				"token":         "",
+				"refresh_token": "",
+			},
+		}
+		s.write(ctx, collection, &s.report.Users, file, i, user.Email, filter, update)
+	}
+	return nil
+}
+
+// apply valida el registro y lo inserta o actualiza. Devuelve si tuvo éxito.
+func (s *seeder) apply(ctx context.Context, collection *mongo.Collection, counts *Counts, file string, index int, key string, record any, filter bson.D, update bson.M) bool {
+	if err := models.Validate(record); err != nil {
+		s.fail(counts, file, index, key, err)
+		return false
+	}
+	return s.write(ctx, collection, counts, file, index, key, filter, update)
+}
+
+func (s *seeder) write(ctx context.Context, collection *mongo.Collection, counts *Counts, file string, index int, key string, filter bson.D, update bson.M) bool {
+	counts.Valid++
+	if s.opts.DryRun {
+		return true
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		counts.Valid--
+		s.fail(counts, file, index, key, err)
+		return false
+	}
+
+	switch {
+	case result.UpsertedCount > 0:
+		counts.Inserted++
+	case result.ModifiedCount > 0:
+		counts.Updated++
+	default:
+		counts.Unchanged++
+	}
+	return true
+}
+
+func (s *seeder) fail(counts *Counts, file string, index int, key string, err error) {
+	counts.Failed++
+	s.report.Errors = append(s.report.Errors, RecordError{File: file, Index: index, Key: key, Err: err})
+}
+
+// readRecords lee un archivo con un arreglo de documentos (o un único
+// documento) en JSON extendido de MongoDB, como el que genera mongoexport.
+func readRecords[T any](path string, out *[]T) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("no se pudo leer %s: %w", path, err)
+	}
+
+	data = bytes.TrimSpace(data)
+	var raws []json.RawMessage
+	if strings.HasPrefix(string(data), "[") {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return fmt.Errorf("JSON inválido en %s: %w", path, err)
+		}
+	} else {
+		raws = []json.RawMessage{data}
+	}
+
+	records := make([]T, len(raws))
+	for i, raw := range raws {
+		if err := bson.UnmarshalExtJSON(raw, false, &records[i]); err != nil {
+			return fmt.Errorf("registro %d inválido en %s: %w", i, path, err)
+		}
+	}
+	*out = records
+	return nil
+}
+
+func isBcryptHash(password string) bool {
+	return len(password) == 60 && (strings.HasPrefix(password, "$2a$") || strings.HasPrefix(password, "$2b$") || strings.HasPrefix(password, "$2y$"))
+}
@@ -0,0 +1,247 @@
+package similar
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// ErrNotFound se devuelve cuando la película no está en el catálogo.
+var ErrNotFound = errors.New("película no encontrada")
+
+// Source entrega el catálogo completo.
+type Source interface {
+	FindAll(ctx context.Context) ([]models.Movie, error)
+}
+
+// Pesos de cada criterio en el puntaje final
+const (
+	genreWeight   = 0.5
+	rankingWeight = 0.2
+	textWeight    = 0.3
+)
+
+const (
+	// Cantidad de resultados guardados por película. Si al quitar los
+	// excluidos quedan menos de los pedidos se calcula la lista completa.
+	maxResults = 50
+	// Vida máxima del modelo, para tomar cambios hechos fuera de la API
+	modelTTL = 10 * time.Minute
+)
+
+// SimilarMovie es una película parecida con su puntaje entre 0 y 1.
+type SimilarMovie struct {
+	models.MovieSummary
+	Score        float64        `json:"score"`
+	SharedGenres []models.Genre `json:"shared_genres"`
+}
+
+// Recommender calcula películas parecidas en memoria. El modelo se construye
+// con la primera consulta y se descarta cuando cambia el catálogo.
+type Recommender struct {
+	source Source
+	now    func() time.Time
+
+	mu    sync.Mutex
+	model *model
+}
+
+type model struct {
+	builtAt time.Time
+	movies  []models.Movie
+	vectors []vector
+	byID    map[string]int
+
+	mu    sync.Mutex
+	cache map[string]ranking
+}
+
+// ranking son los primeros resultados de una película; complete indica que
+// no se recortó.
+type ranking struct {
+	movies   []SimilarMovie
+	complete bool
+}
+
+func NewRecommender(source Source) *Recommender {
+	return &Recommender{source: source, now: time.Now}
+}
+
+// Invalidate descarta el modelo y los resultados guardados.
+func (r *Recommender) Invalidate() {
+	r.mu.Lock()
+	r.model = nil
+	r.mu.Unlock()
+}
+
+// Similar devuelve hasta limit películas parecidas a la indicada, sin las
+// que estén en exclude (por ejemplo las que el usuario ya vio).
+func (r *Recommender) Similar(ctx context.Context, imdbID string, limit int, exclude map[string]bool) ([]SimilarMovie, error) {
+	m, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	top, err := m.similar(imdbID)
+	if err != nil {
+		return nil, err
+	}
+	results := without(top.movies, exclude)
+	if len(results) < limit && !top.complete {
+		// Los excluidos se llevaron parte de los guardados: hay que seguir
+		// más abajo en la lista completa
+		results = without(m.rank(m.byID[imdbID]), exclude)
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// current devuelve el modelo vigente o lo construye si no existe o venció.
+func (r *Recommender) current(ctx context.Context) (*model, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.model != nil && r.now().Sub(r.model.builtAt) < modelTTL {
+		return r.model, nil
+	}
+
+	movies, err := r.source.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.model = buildModel(movies, r.now())
+	return r.model, nil
+}
+
+func buildModel(movies []models.Movie, now time.Time) *model {
+	documents := make([][]string, len(movies))
+	byID := make(map[string]int, len(movies))
+	for i, movie := range movies {
+		documents[i] = terms(movie.Description + " " + movie.AdminReview)
+		byID[movie.ImdbID] = i
+	}
+
+	return &model{
+		builtAt: now,
+		movies:  movies,
+		vectors: tfidf(documents),
+		byID:    byID,
+		cache:   map[string]ranking{},
+	}
+}
+
+// without devuelve las películas que no están en exclude. Los resultados
+// pueden estar en caché: se filtra sobre una copia.
+func without(movies []SimilarMovie, exclude map[string]bool) []SimilarMovie {
+	if len(exclude) == 0 {
+		return movies
+	}
+	kept := make([]SimilarMovie, 0, len(movies))
+	for _, movie := range movies {
+		if !exclude[movie.ImdbID] {
+			kept = append(kept, movie)
+		}
+	}
+	return kept
+}
+
+// similar devuelve los primeros maxResults resultados, guardados en caché.
+func (m *model) similar(imdbID string) (ranking, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if top, ok := m.cache[imdbID]; ok {
+		return top, nil
+	}
+
+	index, ok := m.byID[imdbID]
+	if !ok {
+		return ranking{}, ErrNotFound
+	}
+	results := m.rank(index)
+	top := ranking{movies: results, complete: len(results) <= maxResults}
+	if !top.complete {
+		top.movies = slices.Clone(results[:maxResults])
+	}
+
+	m.cache[imdbID] = top
+	return top, nil
+}
+
+// rank ordena todo el catálogo por parecido con la película index.
+func (m *model) rank(index int) []SimilarMovie {
+	target := m.movies[index]
+
+	results := []SimilarMovie{}
+	for i, movie := range m.movies {
+		if i == index {
+			continue
+		}
+		shared := sharedGenres(target.Genre, movie.Genre)
+		score := genreWeight*jaccard(len(shared), len(target.Genre), len(movie.Genre)) +
+			rankingWeight*rankingProximity(target.Ranking.RankingValue, movie.Ranking.RankingValue) +
+			textWeight*cosine(m.vectors[index], m.vectors[i])
+		if score <= 0 {
+			continue
+		}
+		results = append(results, SimilarMovie{
+			MovieSummary: models.MovieSummary{
+				ImdbID:     movie.ImdbID,
+				Title:      movie.Title,
+				PosterPath: movie.PosterPath,
+			},
+			Score:        score,
+			SharedGenres: shared,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ImdbID < results[j].ImdbID
+	})
+	return results
+}
+
+func sharedGenres(a, b []models.Genre) []models.Genre {
+	ids := make(map[int]bool, len(a))
+	for _, genre := range a {
+		ids[genre.GenreID] = true
+	}
+	shared := []models.Genre{}
+	for _, genre := range b {
+		if ids[genre.GenreID] {
+			shared = append(shared, genre)
+		}
+	}
+	return shared
+}
+
+func jaccard(shared, a, b int) float64 {
+	union := a + b - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// rankingProximity vale 1 si el ranking es igual y 0 si está en el otro
+// extremo de la escala (1 a 5). Sin ranking no suma.
+func rankingProximity(a, b int) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return 1 - float64(diff)/4
+}
@@ -0,0 +1,61 @@
+package similar
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+type fakeSource []models.Movie
+
+func (s fakeSource) FindAll(ctx context.Context) ([]models.Movie, error) {
+	return s, nil
+}
+
+// Los excluidos no pueden dejar la respuesta corta si quedan candidatas más
+// abajo de los resultados guardados.
+func TestSimilarExcludeBeyondCachedResults(t *testing.T) {
+	drama := []models.Genre{{GenreID: 2, GenreName: "Drama"}}
+	catalogue := make(fakeSource, maxResults+20)
+	for i := range catalogue {
+		// El ranking baja con el índice: las primeras son las más parecidas a tt000
+		ranking := 1 + i*4/len(catalogue)
+		catalogue[i] = models.Movie{
+			ImdbID:  fmt.Sprintf("tt%03d", i),
+			Title:   fmt.Sprintf("Película %d", i),
+			Genre:   drama,
+			Ranking: models.Ranking{RankingValue: ranking},
+		}
+	}
+	recommender := NewRecommender(catalogue)
+	ctx := context.Background()
+
+	all, err := recommender.Similar(ctx, "tt000", maxResults, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != maxResults {
+		t.Fatalf("sin excluidos: %d resultados, se esperaban %d", len(all), maxResults)
+	}
+
+	// El usuario ya vio todas las que están en caché
+	exclude := map[string]bool{}
+	for _, movie := range all {
+		exclude[movie.ImdbID] = true
+	}
+	const limit = 10
+	results, err := recommender.Similar(ctx, "tt000", limit, exclude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != limit {
+		t.Fatalf("con excluidos: %d resultados, se esperaban %d", len(results), limit)
+	}
+	for _, movie := range results {
+		if exclude[movie.ImdbID] {
+			t.Errorf("%s está excluida y apareció en los resultados", movie.ImdbID)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package similar
+
+import (
+	"math"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+)
+
+// Palabras muy comunes que no aportan al parecido entre textos
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, word := range []string{
+		"a", "al", "algo", "ante", "aunque", "bien", "cada", "como", "con", "cual",
+		"de", "del", "desde", "donde", "dos", "el", "ella", "ellos", "en", "entre",
+		"era", "es", "esa", "ese", "eso", "esta", "este", "esto", "fue", "ha",
+		"hay", "la", "las", "le", "les", "lo", "los", "mas", "me", "mi", "muy",
+		"ni", "no", "nos", "o", "para", "pero", "por", "que", "se", "ser", "si",
+		"sin", "sobre", "su", "sus", "tambien", "tan", "te", "todo", "tu", "un",
+		"una", "uno", "y", "ya", "yo",
+		"the", "and", "of", "to", "in", "is", "it", "this", "that", "with", "for",
+	} {
+		stopwords[word] = true
+	}
+}
+
+// vector es un vector TF-IDF disperso normalizado a largo 1.
+type vector map[string]float64
+
+func terms(text string) []string {
+	var result []string
+	for _, token := range utils.Tokens(text) {
+		if len(token) > 2 && !stopwords[token] {
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+// tfidf calcula los vectores de cada documento con idf suavizado.
+func tfidf(documents [][]string) []vector {
+	frequency := map[string]int{}
+	for _, document := range documents {
+		seen := map[string]bool{}
+		for _, term := range document {
+			if !seen[term] {
+				seen[term] = true
+				frequency[term]++
+			}
+		}
+	}
+
+	total := float64(len(documents))
+	vectors := make([]vector, len(documents))
+	for i, document := range documents {
+		counts := map[string]float64{}
+		for _, term := range document {
+			counts[term]++
+		}
+
+		v := vector{}
+		var norm float64
+		for term, count := range counts {
+			weight := (count / float64(len(document))) * (math.Log((1+total)/(1+float64(frequency[term]))) + 1)
+			v[term] = weight
+			norm += weight * weight
+		}
+		norm = math.Sqrt(norm)
+		for term := range v {
+			v[term] /= norm
+		}
+		vectors[i] = v
+	}
+	return vectors
+}
+
+// cosine es el producto escalar de dos vectores normalizados.
+func cosine(a, b vector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var sum float64
+	for term, weight := range a {
+		sum += weight * b[term]
+	}
+	return sum
+}
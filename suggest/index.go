@@ -0,0 +1,192 @@
+package suggest
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/utils"
+)
+
+// Source entrega las películas a indexar.
+type Source interface {
+	FindSummaries(ctx context.Context) ([]models.MovieSummary, error)
+}
+
+// Cada cuánto se reconstruye el índice aunque no haya cambios avisados,
+// para tomar cambios hechos desde la línea de comandos u otras instancias.
+const refreshInterval = 5 * time.Minute
+
+// Index es un índice en memoria de títulos para autocompletar. Las búsquedas
+// leen una instantánea inmutable; Refresh la reemplaza de forma atómica.
+type Index struct {
+	source   Source
+	logger   *slog.Logger
+	snapshot atomic.Pointer[snapshot]
+	dirty    chan struct{}
+}
+
+type snapshot struct {
+	movies []entry
+	trie   *trie
+}
+
+type entry struct {
+	summary    models.MovieSummary
+	normalized string
+	words      []string
+	// Palabras unidas por un espacio, para comparar con el texto buscado
+	joined string
+}
+
+func NewIndex(source Source, logger *slog.Logger) *Index {
+	index := &Index{
+		source: source,
+		logger: logger,
+		dirty:  make(chan struct{}, 1),
+	}
+	index.snapshot.Store(build(nil))
+	return index
+}
+
+// Invalidate avisa que el catálogo cambió. No bloquea: varios avisos
+// seguidos producen una sola reconstrucción.
+func (idx *Index) Invalidate() {
+	select {
+	case idx.dirty <- struct{}{}:
+	default:
+	}
+}
+
+// Refresh reconstruye el índice con las películas actuales.
+func (idx *Index) Refresh(ctx context.Context) error {
+	summaries, err := idx.source.FindSummaries(ctx)
+	if err != nil {
+		return err
+	}
+	idx.snapshot.Store(build(summaries))
+	return nil
+}
+
+// Run carga el índice y lo mantiene actualizado hasta que se cancele el contexto.
+func (idx *Index) Run(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	idx.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-idx.dirty:
+			idx.refresh(ctx)
+		case <-ticker.C:
+			idx.refresh(ctx)
+		}
+	}
+}
+
+func (idx *Index) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := idx.Refresh(ctx); err != nil && ctx.Err() == nil {
+		idx.logger.Error("no se pudo actualizar el índice de sugerencias", "error", err)
+	}
+}
+
+// Suggest devuelve hasta limit películas cuyo título tiene palabras que
+// empiezan con cada palabra buscada, sin distinguir mayúsculas ni tildes.
+// Primero van los títulos que empiezan con el texto buscado.
+func (idx *Index) Suggest(query string, limit int) []models.MovieSummary {
+	tokens := utils.Tokens(query)
+	if len(tokens) == 0 || limit <= 0 {
+		return []models.MovieSummary{}
+	}
+
+	snap := idx.snapshot.Load()
+	candidates := snap.trie.lookup(tokens[0])
+	for _, token := range tokens[1:] {
+		if len(candidates) == 0 {
+			break
+		}
+		candidates = intersect(candidates, snap.trie.lookup(token))
+	}
+
+	prefix := strings.Join(tokens, " ")
+	type scored struct {
+		entry    *entry
+		starts   bool
+		position int
+	}
+	matches := make([]scored, 0, len(candidates))
+	for _, i := range candidates {
+		e := &snap.movies[i]
+		matches = append(matches, scored{
+			entry:    e,
+			starts:   strings.HasPrefix(e.joined, prefix),
+			position: firstPosition(e.words, tokens[0]),
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.starts != b.starts {
+			return a.starts
+		}
+		if a.position != b.position {
+			return a.position < b.position
+		}
+		if len(a.entry.normalized) != len(b.entry.normalized) {
+			return len(a.entry.normalized) < len(b.entry.normalized)
+		}
+		return a.entry.normalized < b.entry.normalized
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	results := make([]models.MovieSummary, len(matches))
+	for i, match := range matches {
+		results[i] = match.entry.summary
+	}
+	return results
+}
+
+// Len devuelve la cantidad de películas indexadas.
+func (idx *Index) Len() int {
+	return len(idx.snapshot.Load().movies)
+}
+
+func build(summaries []models.MovieSummary) *snapshot {
+	snap := &snapshot{
+		movies: make([]entry, len(summaries)),
+		trie:   newTrie(),
+	}
+	for i, summary := range summaries {
+		words := utils.Tokens(summary.Title)
+		snap.movies[i] = entry{
+			summary:    summary,
+			normalized: utils.Normalize(summary.Title),
+			words:      words,
+			joined:     strings.Join(words, " "),
+		}
+		for _, word := range words {
+			snap.trie.insert(word, i)
+		}
+	}
+	return snap
+}
+
+// firstPosition devuelve la posición de la primera palabra que empieza con el prefijo.
+func firstPosition(words []string, prefix string) int {
+	for i, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			return i
+		}
+	}
+	return len(words)
+}
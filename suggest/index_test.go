@@ -0,0 +1,109 @@
+package suggest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+type fakeSource []models.MovieSummary
+
+func (s fakeSource) FindSummaries(ctx context.Context) ([]models.MovieSummary, error) {
+	return s, nil
+}
+
+func newTestIndex(t testing.TB, titles ...string) *Index {
+	t.Helper()
+	summaries := make(fakeSource, len(titles))
+	for i, title := range titles {
+		summaries[i] = models.MovieSummary{ImdbID: fmt.Sprintf("tt%07d", i), Title: title}
+	}
+	index := NewIndex(summaries, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := index.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return index
+}
+
+func titles(summaries []models.MovieSummary) []string {
+	result := make([]string, len(summaries))
+	for i, summary := range summaries {
+		result[i] = summary.Title
+	}
+	return result
+}
+
+func TestSuggest(t *testing.T) {
+	index := newTestIndex(t,
+		"El Padrino",
+		"El Padrino II",
+		"Padrinos mágicos",
+		"Mi padre",
+		"¿Y dónde está el piloto?",
+		"Dónde viven los monstruos",
+		"Érase una vez en Hollywood",
+		"Pingüinos de Madagascar",
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{
+			// Primero los títulos que empiezan con el texto, después por
+			// posición de la palabra y por largo
+			name:  "orden",
+			query: "padr",
+			limit: 10,
+			want:  []string{"Padrinos mágicos", "Mi padre", "El Padrino", "El Padrino II"},
+		},
+		{name: "sin tildes", query: "donde", limit: 10, want: []string{"Dónde viven los monstruos", "¿Y dónde está el piloto?"}},
+		{name: "tildes y mayúsculas en la búsqueda", query: "ÉRASE", limit: 10, want: []string{"Érase una vez en Hollywood"}},
+		{name: "diéresis", query: "pinguino", limit: 10, want: []string{"Pingüinos de Madagascar"}},
+		{name: "varias palabras", query: "el padrino ii", limit: 10, want: []string{"El Padrino II"}},
+		{name: "palabras en otro orden", query: "piloto donde", limit: 10, want: []string{"¿Y dónde está el piloto?"}},
+		{name: "límite", query: "padr", limit: 2, want: []string{"Padrinos mágicos", "Mi padre"}},
+		{name: "sin resultados", query: "zzz", limit: 10, want: []string{}},
+		{name: "solo signos", query: "¿?", limit: 10, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := titles(index.Suggest(tt.query, tt.limit)); !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest(%q) = %q, se esperaba %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// Catálogo de 50.000 títulos armados con palabras frecuentes, para medir la
+// latencia de Suggest con un índice de tamaño real (objetivo: menos de 10 ms).
+func BenchmarkSuggest(b *testing.B) {
+	words := strings.Fields("el la los las de del en un una amor noche día sombra ciudad " +
+		"guerra vida muerte padre madre hijo rey reina mar sol luna casa camino última " +
+		"primera historia secreto regreso venganza corazón tiempo perdido fuego hielo " +
+		"estrella viaje isla bosque río montaña sueño misterio destino ángel diablo")
+	rng := rand.New(rand.NewPCG(1, 2))
+	catalogue := make([]string, 50_000)
+	for i := range catalogue {
+		title := make([]string, 1+rng.IntN(5))
+		for j := range title {
+			title[j] = words[rng.IntN(len(words))]
+		}
+		catalogue[i] = strings.Join(title, " ")
+	}
+	index := newTestIndex(b, catalogue...)
+
+	queries := []string{"a", "la", "amo", "el se", "ciudad perdida", "ultima no", "venganza del"}
+	for i := 0; b.Loop(); i++ {
+		index.Suggest(queries[i%len(queries)], 8)
+	}
+}
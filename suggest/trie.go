@@ -0,0 +1,67 @@
+package suggest
+
+// trie indexa palabras y guarda en cada nodo las películas que tienen una
+// palabra con ese prefijo, así una búsqueda cuesta O(largo del prefijo).
+type trie struct {
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// Índices de las películas con alguna palabra que empieza con este prefijo
+	entries []int
+}
+
+func newTrie() *trie {
+	return &trie{root: &trieNode{}}
+}
+
+// insert agrega la palabra para la película indicada. Las películas deben
+// insertarse en orden creciente para que entries quede ordenado y sin repetidos.
+func (t *trie) insert(word string, entry int) {
+	node := t.root
+	for _, char := range word {
+		child := node.children[char]
+		if child == nil {
+			if node.children == nil {
+				node.children = map[rune]*trieNode{}
+			}
+			child = &trieNode{}
+			node.children[char] = child
+		}
+		if n := len(child.entries); n == 0 || child.entries[n-1] != entry {
+			child.entries = append(child.entries, entry)
+		}
+		node = child
+	}
+}
+
+// lookup devuelve las películas con alguna palabra que empieza con el prefijo.
+func (t *trie) lookup(prefix string) []int {
+	node := t.root
+	for _, char := range prefix {
+		node = node.children[char]
+		if node == nil {
+			return nil
+		}
+	}
+	return node.entries
+}
+
+// intersect devuelve los elementos comunes de dos listas ordenadas.
+func intersect(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
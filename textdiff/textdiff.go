@@ -0,0 +1,85 @@
+// Package textdiff compara dos textos palabra por palabra.
+package textdiff
+
+import "strings"
+
+// Tipos de operación de un diff
+const (
+	Equal  = "equal"
+	Insert = "insert"
+	Delete = "delete"
+)
+
+// Op es un tramo del diff: texto sin cambios, agregado o borrado.
+type Op struct {
+	Type string `bson:"type" json:"type"`
+	Text string `bson:"text" json:"text"`
+}
+
+// Máximo de celdas de la tabla LCS. Textos más largos se comparan como un
+// reemplazo completo para no usar demasiada memoria.
+const maxCells = 4_000_000
+
+// Words devuelve las operaciones que transforman old en new. Las palabras se
+// separan por espacios; los tramos consecutivos del mismo tipo se unen.
+func Words(old, new string) []Op {
+	a, b := strings.Fields(old), strings.Fields(new)
+	if len(a)*len(b) > maxCells {
+		return merge(append(ops(Delete, a), ops(Insert, b)...))
+	}
+
+	// lcs[i][j] es el largo de la subsecuencia común más larga de a[i:] y b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var result []Op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, Op{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, Op{Delete, a[i]})
+			i++
+		default:
+			result = append(result, Op{Insert, b[j]})
+			j++
+		}
+	}
+	result = append(result, ops(Delete, a[i:])...)
+	result = append(result, ops(Insert, b[j:])...)
+	return merge(result)
+}
+
+func ops(kind string, words []string) []Op {
+	result := make([]Op, len(words))
+	for i, word := range words {
+		result[i] = Op{kind, word}
+	}
+	return result
+}
+
+func merge(in []Op) []Op {
+	var out []Op
+	for _, op := range in {
+		if n := len(out); n > 0 && out[n-1].Type == op.Type {
+			out[n-1].Text += " " + op.Text
+			continue
+		}
+		out = append(out, op)
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+// Package tracing configura OpenTelemetry: el exportador de spans, la
+// propagación W3C (traceparent) y los spans de pedidos HTTP y de MongoDB.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exportadores de spans soportados (OTEL_TRACES_EXPORTER)
+const (
+	// Envía los spans por OTLP/HTTP a OTEL_EXPORTER_OTLP_ENDPOINT
+	ExporterOTLP = "otlp"
+	// Escribe los spans en la salida de errores, para desarrollo
+	ExporterStdout = "stdout"
+	// No registra spans; solo propaga el traceparent recibido
+	ExporterNone = "none"
+)
+
+// Nombre del tracer de la aplicación
+const tracerName = "github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer"
+
+// Start abre un span hijo del que tenga ctx. Hay que cerrarlo con End.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// Setup registra el proveedor de spans global con el exportador indicado y
+// la propagación W3C. Devuelve la función que envía los spans pendientes y
+// cierra el exportador al terminar.
+func Setup(ctx context.Context, exporter, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		return nil, fmt.Errorf("exportador de trazas desconocido: %q (otlp, stdout o none)", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
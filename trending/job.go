@@ -0,0 +1,81 @@
+package trending
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Source entrega los eventos de actividad agrupados por intervalos.
+type Source interface {
+	ActivityBuckets(ctx context.Context, since time.Time, size time.Duration) ([]models.ActivityBucket, error)
+}
+
+// Sink guarda los puntajes calculados.
+type Sink interface {
+	SetPopularity(ctx context.Context, scores map[string]models.Popularity, updatedAt time.Time) error
+}
+
+const (
+	// Cada cuánto se recalculan los puntajes
+	refreshInterval = 5 * time.Minute
+	// Tiempo máximo de cada recálculo
+	refreshTimeout = time.Minute
+)
+
+// Job recalcula la popularidad de las películas en segundo plano.
+type Job struct {
+	source  Source
+	sink    Sink
+	logger  *slog.Logger
+	now     func() time.Time
+	timeout time.Duration
+}
+
+// NewJob crea el trabajo. now es el reloj usado para el cálculo; si es nil
+// se usa time.Now.
+func NewJob(source Source, sink Sink, logger *slog.Logger, now func() time.Time) *Job {
+	if now == nil {
+		now = time.Now
+	}
+	return &Job{source: source, sink: sink, logger: logger, now: now, timeout: refreshTimeout}
+}
+
+// Refresh recalcula y guarda los puntajes con la actividad de la ventana más larga.
+func (j *Job) Refresh(ctx context.Context) error {
+	now := j.now()
+	buckets, err := j.source.ActivityBuckets(ctx, now.Add(-Weekly.Span-BucketSize), BucketSize)
+	if err != nil {
+		return err
+	}
+	return j.sink.SetPopularity(ctx, Score(buckets, now), now)
+}
+
+// Run recalcula los puntajes al empezar y cada cinco minutos hasta que se
+// cancele el contexto.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	j.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.refresh(ctx)
+		}
+	}
+}
+
+// refresh recalcula con un tiempo máximo y registra los errores, también si
+// se agotó el tiempo; solo calla si se canceló el trabajo (al apagarse).
+func (j *Job) refresh(parent context.Context) {
+	ctx, cancel := context.WithTimeout(parent, j.timeout)
+	defer cancel()
+	if err := j.Refresh(ctx); err != nil && parent.Err() == nil {
+		j.logger.Error("no se pudo actualizar la popularidad", "error", err)
+	}
+}
@@ -0,0 +1,131 @@
+package trending
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+type fakeSource struct {
+	buckets []models.ActivityBucket
+	err     error
+
+	since time.Time
+	size  time.Duration
+}
+
+func (s *fakeSource) ActivityBuckets(ctx context.Context, since time.Time, size time.Duration) ([]models.ActivityBucket, error) {
+	s.since, s.size = since, size
+	return s.buckets, s.err
+}
+
+// slowSource no responde hasta que se cancela el contexto, como una consulta
+// que no termina a tiempo.
+type slowSource struct{}
+
+func (slowSource) ActivityBuckets(ctx context.Context, since time.Time, size time.Duration) ([]models.ActivityBucket, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type fakeSink struct {
+	calls     int
+	scores    map[string]models.Popularity
+	updatedAt time.Time
+}
+
+func (s *fakeSink) SetPopularity(ctx context.Context, scores map[string]models.Popularity, updatedAt time.Time) error {
+	s.calls++
+	s.scores, s.updatedAt = scores, updatedAt
+	return nil
+}
+
+func newTestJob(source Source, sink Sink) *Job {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewJob(source, sink, logger, func() time.Time { return testNow })
+}
+
+func TestJobRefresh(t *testing.T) {
+	source := &fakeSource{buckets: []models.ActivityBucket{
+		bucketAt("tt1", models.ActivityView, 4, 10*time.Minute),
+		bucketAt("tt2", models.ActivityRating, 1, 30*time.Hour),
+	}}
+	sink := &fakeSink{}
+
+	if err := newTestJob(source, sink).Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pide la ventana más larga con un intervalo de margen
+	if want := testNow.Add(-Weekly.Span - BucketSize); !source.since.Equal(want) {
+		t.Errorf("since = %v, se esperaba %v", source.since, want)
+	}
+	if source.size != BucketSize {
+		t.Errorf("size = %v, se esperaba %v", source.size, BucketSize)
+	}
+	if sink.calls != 1 {
+		t.Fatalf("SetPopularity se llamó %d veces, se esperaba 1", sink.calls)
+	}
+	if !sink.updatedAt.Equal(testNow) {
+		t.Errorf("updatedAt = %v, se esperaba el reloj del trabajo %v", sink.updatedAt, testNow)
+	}
+	if want := Score(source.buckets, testNow); !maps.Equal(sink.scores, want) {
+		t.Errorf("puntajes = %+v, se esperaba %+v", sink.scores, want)
+	}
+	if got := sink.scores["tt2"]; got.Hourly != 0 || got.Daily != 0 || got.Weekly == 0 {
+		t.Errorf("tt2 solo debería puntuar en la semana: %+v", got)
+	}
+}
+
+func TestJobRefreshSourceError(t *testing.T) {
+	errSource := errors.New("sin conexión")
+	sink := &fakeSink{}
+
+	err := newTestJob(&fakeSource{err: errSource}, sink).Refresh(context.Background())
+	if !errors.Is(err, errSource) {
+		t.Fatalf("error = %v, se esperaba %v", err, errSource)
+	}
+	if sink.calls != 0 {
+		t.Error("no debería guardar puntajes si falla la lectura")
+	}
+}
+
+func TestJobRefreshLogsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  Source
+		cancel  bool
+		wantLog bool
+	}{
+		{name: "se agotó el tiempo", source: slowSource{}, wantLog: true},
+		{name: "falla la lectura", source: &fakeSource{err: errors.New("sin conexión")}, wantLog: true},
+		// Al apagarse el servidor la cancelación no es un error
+		{name: "trabajo cancelado", source: slowSource{}, cancel: true, wantLog: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			job := NewJob(tt.source, &fakeSink{}, slog.New(slog.NewTextHandler(&out, nil)), func() time.Time { return testNow })
+			job.timeout = 10 * time.Millisecond
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			job.refresh(ctx)
+
+			if logged := strings.Contains(out.String(), "no se pudo actualizar la popularidad"); logged != tt.wantLog {
+				t.Errorf("registró el error: %v, se esperaba %v (log: %q)", logged, tt.wantLog, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package trending
+
+import (
+	"math"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+// Window es una ventana de popularidad: solo cuentan los eventos de los
+// últimos Span, y cada evento pierde la mitad de su peso cada HalfLife.
+type Window struct {
+	Name     string
+	Span     time.Duration
+	HalfLife time.Duration
+}
+
+var (
+	Hourly = Window{Name: "hourly", Span: time.Hour, HalfLife: 20 * time.Minute}
+	Daily  = Window{Name: "daily", Span: 24 * time.Hour, HalfLife: 6 * time.Hour}
+	Weekly = Window{Name: "weekly", Span: 7 * 24 * time.Hour, HalfLife: 2 * 24 * time.Hour}
+
+	Windows = []Window{Hourly, Daily, Weekly}
+)
+
+// WindowByName busca una ventana por nombre.
+func WindowByName(name string) (Window, bool) {
+	for _, window := range Windows {
+		if window.Name == name {
+			return window, true
+		}
+	}
+	return Window{}, false
+}
+
+// Duración de los intervalos en que se agrupan los eventos
+const BucketSize = 5 * time.Minute
+
+// Peso de cada tipo de actividad: agregar a pendientes o calificar dice más
+// que abrir la ficha.
+var weights = map[models.ActivityKind]float64{
+	models.ActivityView:      1,
+	models.ActivityWatchlist: 2,
+	models.ActivityRating:    3,
+}
+
+// Score calcula la popularidad de cada película en cada ventana a partir de
+// los eventos agrupados, tomando now como el momento actual. Cada intervalo
+// cuenta con la edad de su punto medio. Solo depende de sus argumentos, así
+// el mismo reloj da siempre el mismo resultado.
+func Score(buckets []models.ActivityBucket, now time.Time) map[string]models.Popularity {
+	scores := map[string]models.Popularity{}
+	for _, bucket := range buckets {
+		weight := weights[bucket.Kind] * float64(bucket.Count)
+		if weight == 0 {
+			continue
+		}
+
+		age := now.Sub(bucket.Start.Add(BucketSize / 2))
+		if age < 0 {
+			age = 0
+		}
+
+		popularity := scores[bucket.ImdbID]
+		popularity.Hourly += decayed(weight, age, Hourly)
+		popularity.Daily += decayed(weight, age, Daily)
+		popularity.Weekly += decayed(weight, age, Weekly)
+		scores[bucket.ImdbID] = popularity
+	}
+
+	for imdbID, popularity := range scores {
+		popularity.Hourly = round(popularity.Hourly)
+		popularity.Daily = round(popularity.Daily)
+		popularity.Weekly = round(popularity.Weekly)
+		popularity.UpdatedAt = now
+		scores[imdbID] = popularity
+	}
+	return scores
+}
+
+func decayed(weight float64, age time.Duration, window Window) float64 {
+	if age > window.Span {
+		return 0
+	}
+	return weight * math.Exp2(-float64(age)/float64(window.HalfLife))
+}
+
+// round deja cuatro decimales para que el orden no dependa de errores de redondeo.
+func round(score float64) float64 {
+	return math.Round(score*10000) / 10000
+}
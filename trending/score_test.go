@@ -0,0 +1,110 @@
+package trending
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/models"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// bucketAt arma un intervalo cuyo punto medio tiene la edad indicada.
+func bucketAt(imdbID string, kind models.ActivityKind, count int, age time.Duration) models.ActivityBucket {
+	return models.ActivityBucket{
+		ImdbID: imdbID,
+		Kind:   kind,
+		Start:  testNow.Add(-age - BucketSize/2),
+		Count:  count,
+	}
+}
+
+// half es el peso que queda de 1 después de age con la vida media indicada.
+func half(age, halfLife time.Duration) float64 {
+	return round(math.Exp2(-float64(age) / float64(halfLife)))
+}
+
+func TestScoreWindows(t *testing.T) {
+	tests := []struct {
+		name string
+		age  time.Duration
+		want models.Popularity
+	}{
+		{name: "recién", age: 0, want: models.Popularity{Hourly: 1, Daily: 1, Weekly: 1}},
+		{
+			name: "una vida media de la hora",
+			age:  20 * time.Minute,
+			want: models.Popularity{Hourly: 0.5, Daily: half(20*time.Minute, Daily.HalfLife), Weekly: half(20*time.Minute, Weekly.HalfLife)},
+		},
+		{
+			name: "borde de la hora",
+			age:  time.Hour,
+			want: models.Popularity{Hourly: 0.125, Daily: half(time.Hour, Daily.HalfLife), Weekly: half(time.Hour, Weekly.HalfLife)},
+		},
+		{
+			name: "fuera de la hora",
+			age:  time.Hour + time.Second,
+			want: models.Popularity{Daily: half(time.Hour+time.Second, Daily.HalfLife), Weekly: half(time.Hour+time.Second, Weekly.HalfLife)},
+		},
+		{
+			name: "borde del día",
+			age:  24 * time.Hour,
+			want: models.Popularity{Daily: 0.0625, Weekly: half(24*time.Hour, Weekly.HalfLife)},
+		},
+		{
+			name: "fuera del día",
+			age:  24*time.Hour + time.Second,
+			want: models.Popularity{Weekly: half(24*time.Hour+time.Second, Weekly.HalfLife)},
+		},
+		{name: "una vida media de la semana", age: 48 * time.Hour, want: models.Popularity{Weekly: 0.5}},
+		{name: "borde de la semana", age: Weekly.Span, want: models.Popularity{Weekly: half(Weekly.Span, Weekly.HalfLife)}},
+		{name: "fuera de la semana", age: Weekly.Span + time.Second, want: models.Popularity{}},
+		// Un intervalo con reloj adelantado cuenta como actual
+		{name: "en el futuro", age: -time.Hour, want: models.Popularity{Hourly: 1, Daily: 1, Weekly: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := Score([]models.ActivityBucket{bucketAt("tt1", models.ActivityView, 1, tt.age)}, testNow)
+			tt.want.UpdatedAt = testNow
+			if got := scores["tt1"]; got != tt.want {
+				t.Errorf("Score a los %v = %+v, se esperaba %+v", tt.age, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreWeights(t *testing.T) {
+	buckets := []models.ActivityBucket{
+		bucketAt("tt1", models.ActivityView, 2, 0),
+		bucketAt("tt1", models.ActivityWatchlist, 1, 0),
+		bucketAt("tt1", models.ActivityRating, 1, 20*time.Minute),
+		bucketAt("tt2", models.ActivityKind("desconocido"), 10, 0),
+	}
+	scores := Score(buckets, testNow)
+
+	// 2 vistas + 1 pendiente (peso 2) + 1 calificación (peso 3) con media vida
+	if got, want := scores["tt1"].Hourly, 2+2+1.5; got != want {
+		t.Errorf("tt1: Hourly = %v, se esperaba %v", got, want)
+	}
+	if _, ok := scores["tt2"]; ok {
+		t.Error("tt2 solo tiene actividad sin peso y no debería tener puntaje")
+	}
+}
+
+func TestScoreIsDeterministic(t *testing.T) {
+	buckets := []models.ActivityBucket{
+		bucketAt("tt1", models.ActivityView, 3, 7*time.Minute),
+		bucketAt("tt1", models.ActivityRating, 1, 13*time.Hour),
+		bucketAt("tt2", models.ActivityWatchlist, 5, 3*24*time.Hour),
+	}
+	first := Score(buckets, testNow)
+	for range 10 {
+		again := Score(buckets, testNow)
+		for imdbID, popularity := range first {
+			if again[imdbID] != popularity {
+				t.Fatalf("%s: %+v y después %+v con el mismo reloj", imdbID, popularity, again[imdbID])
+			}
+		}
+	}
+}
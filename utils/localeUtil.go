@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Obtener el idioma del pedido desde contexto de gin
+func GetLocaleFromContext(c *gin.Context) string {
+	if locale, ok := c.Get("locale"); ok {
+		if value, ok := locale.(string); ok {
+			return value
+		}
+	}
+	return i18n.Default
+}
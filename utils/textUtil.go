@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize pasa el texto a minúsculas y quita tildes y diéresis, igual que
+// la collation "es" con strength 1: "Érase" y "erase" quedan iguales.
+func Normalize(text string) string {
+	var builder strings.Builder
+	builder.Grow(len(text))
+	for _, char := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, char) {
+			continue
+		}
+		builder.WriteRune(unicode.ToLower(char))
+	}
+	return builder.String()
+}
+
+// Tokens divide el texto normalizado en palabras, ignorando signos de
+// puntuación ("¿Y dónde está el piloto?" → y, donde, esta, el, piloto).
+func Tokens(text string) []string {
+	return strings.FieldsFunc(Normalize(text), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsNumber(char)
+	})
+}
+
+// Slugify arma un identificador para URLs a partir del texto: palabras
+// normalizadas unidas por guiones ("Mis Películas Favoritas" → mis-peliculas-favoritas).
+func Slugify(text string, maxLength int) string {
+	slug := strings.Join(Tokens(text), "-")
+	if runes := []rune(slug); len(runes) > maxLength {
+		slug = strings.TrimRight(string(runes[:maxLength]), "-")
+	}
+	return slug
+}
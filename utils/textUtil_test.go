@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Érase una vez", "erase una vez"},
+		{"PINGÜINO", "pinguino"},
+		{"Año Nuevo", "ano nuevo"},
+		{"Amélie", "amelie"},
+		{"ya normalizado", "ya normalizado"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.text); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, se esperaba %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"¿Y dónde está el piloto?", []string{"y", "donde", "esta", "el", "piloto"}},
+		{"Spider-Man: No Way Home", []string{"spider", "man", "no", "way", "home"}},
+		{"2001: Odisea del espacio", []string{"2001", "odisea", "del", "espacio"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		if got := Tokens(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokens(%q) = %q, se esperaba %q", tt.text, got, tt.want)
+		}
+	}
+}
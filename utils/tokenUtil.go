@@ -3,14 +3,11 @@ package utils
 import (
 	"context"
 	"errors"
-	"os"
 	"time"
 
-	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/database"
+	"github.com/Juanemiliani70/PeliculApp/Server/PeliculAppServer/tracing"
 	"github.com/gin-gonic/gin"
 	jwt "github.com/golang-jwt/jwt/v5"
-	"go.mongodb.org/mongo-driver/v2/bson"
-	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type SignedDetails struct {
@@ -22,10 +19,23 @@ type SignedDetails struct {
 	jwt.RegisteredClaims
 }
 
-var SECRET_KEY string = os.Getenv("SECRET_KEY")
-var SECRET_REFRESH_KEY string = os.Getenv("SECRET_REFRESH_KEY")
+// TokenService genera y valida los tokens JWT con las claves configuradas.
+type TokenService struct {
+	secretKey        []byte
+	secretRefreshKey []byte
+}
+
+func NewTokenService(secretKey, secretRefreshKey string) *TokenService {
+	return &TokenService{
+		secretKey:        []byte(secretKey),
+		secretRefreshKey: []byte(secretRefreshKey),
+	}
+}
+
+func (s *TokenService) GenerateAllTokens(ctx context.Context, email, firstName, lastName, role, userId string) (string, string, error) {
+	_, span := tracing.Start(ctx, "jwt.generate")
+	defer span.End()
 
-func GenerateAllTokens(email, firstName, lastName, role, userId string) (string, string, error) {
 	claims := &SignedDetails{
 		Email:     email,
 		FirstName: firstName,
@@ -39,7 +49,7 @@ func GenerateAllTokens(email, firstName, lastName, role, userId string) (string,
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(SECRET_KEY))
+	signedToken, err := token.SignedString(s.secretKey)
 	if err != nil {
 		return "", "", err
 	}
@@ -57,7 +67,7 @@ func GenerateAllTokens(email, firstName, lastName, role, userId string) (string,
 		},
 	}
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	signedRefreshToken, err := refreshToken.SignedString([]byte(SECRET_REFRESH_KEY))
+	signedRefreshToken, err := refreshToken.SignedString(s.secretRefreshKey)
 	if err != nil {
 		return "", "", err
 	}
@@ -65,25 +75,6 @@ func GenerateAllTokens(email, firstName, lastName, role, userId string) (string,
 	return signedToken, signedRefreshToken, nil
 }
 
-// Actualiza tokens en la base de datos
-func UpdateAllTokens(userId, token, refreshToken string, client *mongo.Client) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
-	defer cancel()
-
-	updateAt := time.Now().Format(time.RFC3339)
-	updateData := bson.M{
-		"$set": bson.M{
-			"token":         token,
-			"refresh_token": refreshToken,
-			"update_at":     updateAt,
-		},
-	}
-
-	userCollection := database.OpenCollection("users", client)
-	_, err := userCollection.UpdateOne(ctx, bson.M{"user_id": userId}, updateData)
-	return err
-}
-
 // Obtiene el access token desde las cookies
 func GetAccessToken(c *gin.Context) (string, error) {
 	tokenString, err := c.Cookie("access_token")
@@ -94,10 +85,13 @@ func GetAccessToken(c *gin.Context) (string, error) {
 }
 
 // Valida un token JWT normal
-func ValidateToken(tokenString string) (*SignedDetails, error) {
+func (s *TokenService) ValidateToken(ctx context.Context, tokenString string) (*SignedDetails, error) {
+	_, span := tracing.Start(ctx, "jwt.validate")
+	defer span.End()
+
 	claims := &SignedDetails{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(SECRET_KEY), nil
+		return s.secretKey, nil
 	})
 	if err != nil {
 		return nil, err
@@ -115,10 +109,13 @@ func ValidateToken(tokenString string) (*SignedDetails, error) {
 }
 
 // Valida refresh token
-func ValidateRefreshToken(tokenString string) (*SignedDetails, error) {
+func (s *TokenService) ValidateRefreshToken(ctx context.Context, tokenString string) (*SignedDetails, error) {
+	_, span := tracing.Start(ctx, "jwt.validate_refresh")
+	defer span.End()
+
 	claims := &SignedDetails{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(SECRET_REFRESH_KEY), nil
+		return s.secretRefreshKey, nil
 	})
 	if err != nil {
 		return nil, err